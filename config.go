@@ -2,7 +2,6 @@ package configuration
 
 import (
 	"math/big"
-	"strings"
 	"time"
 
 	"github.com/go-akka/configuration/hocon"
@@ -67,7 +66,7 @@ func (p *Config) GetNode(path string) *hocon.HoconValue {
 		return nil
 	}
 
-	elements := splitDottedPathHonouringQuotes(path)
+	elements := hocon.SplitPath(path)
 	currentNode := p.root
 
 	if currentNode == nil {
@@ -86,8 +85,18 @@ func (p *Config) GetNode(path string) *hocon.HoconValue {
 	return currentNode
 }
 
+// getValue returns the node at path, or nil if the path is missing or set
+// to null. The Get getters return their default value in both cases.
+func (p *Config) getValue(path string) *hocon.HoconValue {
+	node := p.GetNode(path)
+	if node == nil || node.IsNull() {
+		return nil
+	}
+	return node
+}
+
 func (p *Config) GetBoolean(path string, defaultVal ...bool) bool {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		if len(defaultVal) > 0 {
 			return defaultVal[0]
@@ -98,7 +107,7 @@ func (p *Config) GetBoolean(path string, defaultVal ...bool) bool {
 }
 
 func (p *Config) GetByteSize(path string) *big.Int {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		return big.NewInt(-1)
 	}
@@ -106,7 +115,7 @@ func (p *Config) GetByteSize(path string) *big.Int {
 }
 
 func (p *Config) GetInt32(path string, defaultVal ...int32) int32 {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		if len(defaultVal) > 0 {
 			return defaultVal[0]
@@ -117,7 +126,7 @@ func (p *Config) GetInt32(path string, defaultVal ...int32) int32 {
 }
 
 func (p *Config) GetInt64(path string, defaultVal ...int64) int64 {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		if len(defaultVal) > 0 {
 			return defaultVal[0]
@@ -127,8 +136,30 @@ func (p *Config) GetInt64(path string, defaultVal ...int64) int64 {
 	return obj.GetInt64()
 }
 
+func (p *Config) GetBigInt(path string, defaultVal ...*big.Int) *big.Int {
+	obj := p.getValue(path)
+	if obj == nil {
+		if len(defaultVal) > 0 {
+			return defaultVal[0]
+		}
+		return nil
+	}
+	return obj.GetBigInt()
+}
+
+func (p *Config) GetBigFloat(path string, defaultVal ...*big.Float) *big.Float {
+	obj := p.getValue(path)
+	if obj == nil {
+		if len(defaultVal) > 0 {
+			return defaultVal[0]
+		}
+		return nil
+	}
+	return obj.GetBigFloat()
+}
+
 func (p *Config) GetString(path string, defaultVal ...string) string {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		if len(defaultVal) > 0 {
 			return defaultVal[0]
@@ -139,17 +170,18 @@ func (p *Config) GetString(path string, defaultVal ...string) string {
 }
 
 func (p *Config) GetFloat32(path string, defaultVal ...float32) float32 {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		if len(defaultVal) > 0 {
 			return defaultVal[0]
 		}
+		return 0
 	}
 	return obj.GetFloat32()
 }
 
 func (p *Config) GetFloat64(path string, defaultVal ...float64) float64 {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		if len(defaultVal) > 0 {
 			return defaultVal[0]
@@ -159,8 +191,10 @@ func (p *Config) GetFloat64(path string, defaultVal ...float64) float64 {
 	return obj.GetFloat64()
 }
 
+// GetTimeDuration reads infinite as -1. GetDuration reports it with
+// Duration.Infinite instead.
 func (p *Config) GetTimeDuration(path string, defaultVal ...time.Duration) time.Duration {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		if len(defaultVal) > 0 {
 			return defaultVal[0]
@@ -171,7 +205,7 @@ func (p *Config) GetTimeDuration(path string, defaultVal ...time.Duration) time.
 }
 
 func (p *Config) GetTimeDurationInfiniteNotAllowed(path string, defaultVal ...time.Duration) time.Duration {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		if len(defaultVal) > 0 {
 			return defaultVal[0]
@@ -182,7 +216,7 @@ func (p *Config) GetTimeDurationInfiniteNotAllowed(path string, defaultVal ...ti
 }
 
 func (p *Config) GetBooleanList(path string) []bool {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		return nil
 	}
@@ -190,7 +224,7 @@ func (p *Config) GetBooleanList(path string) []bool {
 }
 
 func (p *Config) GetFloat32List(path string) []float32 {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		return nil
 	}
@@ -198,7 +232,7 @@ func (p *Config) GetFloat32List(path string) []float32 {
 }
 
 func (p *Config) GetFloat64List(path string) []float64 {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		return nil
 	}
@@ -206,7 +240,7 @@ func (p *Config) GetFloat64List(path string) []float64 {
 }
 
 func (p *Config) GetInt32List(path string) []int32 {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		return nil
 	}
@@ -214,7 +248,7 @@ func (p *Config) GetInt32List(path string) []int32 {
 }
 
 func (p *Config) GetInt64List(path string) []int64 {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		return nil
 	}
@@ -222,7 +256,7 @@ func (p *Config) GetInt64List(path string) []int64 {
 }
 
 func (p *Config) GetByteList(path string) []byte {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		return nil
 	}
@@ -230,7 +264,7 @@ func (p *Config) GetByteList(path string) []byte {
 }
 
 func (p *Config) GetStringList(path string) []string {
-	obj := p.GetNode(path)
+	obj := p.getValue(path)
 	if obj == nil {
 		return nil
 	}
@@ -251,7 +285,10 @@ func (p *Config) GetConfig(path string) *Config {
 		if value == nil {
 			return f
 		}
-		return NewConfigFromRoot(hocon.NewHoconRoot(value)).WithFallback(f)
+		// only objects merge with their fallback, other values replace it
+		if f != nil && value.IsObject() && f.root.IsObject() {
+			return NewConfigFromRoot(hocon.NewHoconRoot(value)).WithFallback(f)
+		}
 	}
 
 	if value == nil {
@@ -273,6 +310,10 @@ func (p *Config) WithFallback(fallback *Config) *Config {
 		return p
 	}
 
+	if p.IsList() || fallback.IsList() {
+		panic("Config with an array at its root can not be merged with a fallback, an object is required")
+	}
+
 	mergedRoot := p.root.GetObject().MergeImmutable(fallback.root.GetObject())
 	newRoot := hocon.NewHoconValue()
 
@@ -322,17 +363,3 @@ func (p *Config) AddConfigWithTextFallback(config *Config, textFallback string)
 func (p Config) String() string {
 	return p.root.String()
 }
-
-func splitDottedPathHonouringQuotes(path string) []string {
-	tmp1 := strings.Split(path, "\"")
-	var values []string
-	for i := 0; i < len(tmp1); i++ {
-		tmp2 := strings.Split(tmp1[i], ".")
-		for j := 0; j < len(tmp2); j++ {
-			if len(tmp2[j]) > 0 {
-				values = append(values, tmp2[j])
-			}
-		}
-	}
-	return values
-}
@@ -2,7 +2,9 @@ package configuration
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/go-akka/configuration/hocon"
 )
@@ -18,13 +20,52 @@ func ParseString(text string, includeCallback ...hocon.IncludeCallback) *Config
 	return NewConfigFromRoot(root)
 }
 
+// ParseProperties reads a Java .properties document into a Config. Dotted
+// keys become nested objects and every value is a string.
+func ParseProperties(r io.Reader) *Config {
+	return NewConfigFromRoot(hocon.ParseProperties(r))
+}
+
+// ParseJSON parses text as strict JSON. HOCON extensions such as comments,
+// unquoted strings or substitutions make it panic with a *hocon.ParseError
+// that carries the line and column of the violation.
+func ParseJSON(text string) *Config {
+	root := hocon.ParseWithOptions(text, hocon.ParseOptions{Syntax: hocon.SyntaxJSON})
+	return NewConfigFromRoot(root)
+}
+
+// ParseStringWithOptions parses text with the given parser options. The
+// default include callback is used when options.Callback is nil.
+func ParseStringWithOptions(text string, options hocon.ParseOptions) *Config {
+	if options.Callback == nil {
+		options.Callback = defaultIncludeCallback
+	}
+	root := hocon.ParseWithOptions(text, options)
+	return NewConfigFromRoot(root)
+}
+
+// ParseReader parses the HOCON document read from r without loading it into
+// memory first.
+func ParseReader(r io.Reader, includeCallback ...hocon.IncludeCallback) *Config {
+	var callback hocon.IncludeCallback
+	if len(includeCallback) > 0 {
+		callback = includeCallback[0]
+	} else {
+		callback = defaultIncludeCallback
+	}
+	root := hocon.ParseReader(r, callback)
+	return NewConfigFromRoot(root)
+}
+
 func LoadConfig(filename string) *Config {
-	data, err := ioutil.ReadFile(filename)
+	file, err := os.Open(filename)
 	if err != nil {
 		panic(err)
 	}
+	defer file.Close()
 
-	return ParseString(string(data), defaultIncludeCallback)
+	options := hocon.ParseOptions{Callback: defaultIncludeCallback, Filename: filename}
+	return NewConfigFromRoot(hocon.ParseReaderWithOptions(file, options))
 }
 
 func FromObject(obj interface{}) *Config {
@@ -37,10 +78,16 @@ func FromObject(obj interface{}) *Config {
 }
 
 func defaultIncludeCallback(filename string) *hocon.HoconRoot {
-	data, err := ioutil.ReadFile(filename)
+	file, err := os.Open(filename)
 	if err != nil {
 		panic(err)
 	}
+	defer file.Close()
+
+	if strings.HasSuffix(filename, ".properties") {
+		return hocon.ParseProperties(file)
+	}
 
-	return hocon.Parse(string(data), defaultIncludeCallback)
+	options := hocon.ParseOptions{Callback: defaultIncludeCallback, Filename: filename}
+	return hocon.ParseReaderWithOptions(file, options)
 }
@@ -1,12 +1,40 @@
 package configuration
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
+
+	"github.com/go-akka/configuration/hocon"
 )
 
+// mustPanic runs fn and returns the value it panicked with. The test fails
+// if fn returns normally.
+func mustPanic(t *testing.T, name string, fn func()) (r interface{}) {
+	t.Helper()
+	defer func() {
+		if r = recover(); r == nil {
+			t.Errorf("%s: expected a panic", name)
+		}
+	}()
+	fn()
+	return
+}
+
+// assertValues checks that each path in expected reads as its string.
+func assertValues(t *testing.T, conf *Config, expected map[string]string) {
+	t.Helper()
+	for path, value := range expected {
+		if v := conf.GetString(path); v != value {
+			t.Errorf("%s, expected: %q, real: %q", path, value, v)
+		}
+	}
+}
+
 func TestParseKeyOrder(t *testing.T) {
 
 	wg := &sync.WaitGroup{}
@@ -26,7 +54,7 @@ func TestParseKeyOrder(t *testing.T) {
 
 					if order != int32(i) {
 						fmt.Println(conf)
-						t.Fatalf("order not match,group %d, except: %d, real order: %d", g, i, order)
+						t.Errorf("order not match,group %d, except: %d, real order: %d", g, i, order)
 						return
 					}
 				}
@@ -43,3 +71,474 @@ func TestParseKeyOrder(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestStringRoundTrip(t *testing.T) {
+	for _, filename := range []string{"tests/configs.conf", "pigeon.conf"} {
+		conf := LoadConfig(filename)
+		text := conf.String()
+		reparsed := ParseString(text)
+
+		if reparsed.String() != text {
+			t.Fatalf("%s: rendering does not survive a parse round trip:\n%s\n---\n%s", filename, text, reparsed.String())
+		}
+	}
+}
+
+func largeDocument(keys int) string {
+	var text strings.Builder
+	for i := 0; i < keys; i++ {
+		fmt.Fprintf(&text, "key%d = \"value %d\"\r\n", i, i)
+	}
+	return text.String()
+}
+
+func TestLargeDocumentOrigins(t *testing.T) {
+	const keys = 20000
+	text := largeDocument(keys)
+
+	for _, conf := range []*Config{ParseString(text), ParseReader(strings.NewReader(text))} {
+		for _, i := range []int{0, keys / 2, keys - 1} {
+			path := fmt.Sprintf("key%d", i)
+			if line := conf.GetNode(path).Origin().Line; line != i+1 {
+				t.Fatalf("%s, expected line %d, real: %d", path, i+1, line)
+			}
+		}
+	}
+}
+
+func TestIncludeLookahead(t *testing.T) {
+	text := "a = 1\ninclude" + strings.Repeat(" ", hocon.MaxLookahead) + "\"b.conf\"\n"
+	err, _ := mustPanic(t, "lookahead", func() { ParseReader(strings.NewReader(text)) }).(*hocon.ParseError)
+	if err == nil || !strings.HasPrefix(err.Message, "lookahead of more than") {
+		t.Fatalf("expected a lookahead error, real: %v", err)
+	}
+
+	err, _ = mustPanic(t, "unterminated include", func() { ParseString("a = 1\ninclude \"b.conf\n") }).(*hocon.ParseError)
+	if err == nil || err.Line != 2 {
+		t.Fatalf("expected an error at line 2, real: %v", err)
+	}
+}
+
+func BenchmarkParseLargeDocument(b *testing.B) {
+	text := largeDocument(20000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ParseString(text)
+	}
+}
+
+func BenchmarkParseNumbers(b *testing.B) {
+	var text strings.Builder
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(&text, "n%d = [%d, -%d.5, %de3, 1.5E-2, 10s]\n", i, i, i, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ParseString(text.String())
+	}
+}
+
+func TestParseProperties(t *testing.T) {
+	conf := ParseProperties(strings.NewReader("a.b=1\na=overridden\na.c = two words\nd:x\nd:y\n"))
+
+	if v := conf.GetInt32("a.b"); v != 1 {
+		t.Fatalf("a.b, expected: 1, real: %d", v)
+	}
+	if v := conf.GetString("a.c"); v != "two words" {
+		t.Fatalf("a.c, expected: two words, real: %s", v)
+	}
+	if v := conf.GetString("d"); v != "y" {
+		t.Fatalf("d, expected: y, real: %s", v)
+	}
+}
+
+func TestIncludeProperties(t *testing.T) {
+	conf := LoadConfig("tests/t3.conf")
+
+	if v := conf.GetString("akka.loglevel"); v != "INFO" {
+		t.Fatalf("akka.loglevel, expected: INFO, real: %s", v)
+	}
+	if v := conf.GetInt32("akka.remote.port"); v != 2552 {
+		t.Fatalf("akka.remote.port, expected: 2552, real: %d", v)
+	}
+	if v := conf.GetString("akka.cluster.seed-nodes"); v != "akka://a@host:2552, akka://b@host:2552" {
+		t.Fatalf("akka.cluster.seed-nodes, unexpected value: %s", v)
+	}
+	if v := conf.GetString("greeting"); v != "café" {
+		t.Fatalf("greeting, expected: café, real: %s", v)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	conf := ParseJSON(`{"a": {"b": [1, 2.5e+3, true, null, "x y"]}, "c": "d"}`)
+	if v := conf.GetStringList("a.b"); strings.Join(v, ",") != "1,2.5e+3,true,,x y" {
+		t.Fatalf("a.b, unexpected value: %v", v)
+	}
+	if v := ParseJSON(`{"a": "x\ty"}`).GetString("a"); v != "x\ty" {
+		t.Fatalf("a, expected an escaped tab, real: %q", v)
+	}
+
+	violations := map[string]string{
+		"{\"a\": 1,\n \"b\": 2,}":  "trailing commas are not allowed in JSON (line 2, column 9)",
+		"{\"a\": [1, 2,]}":         "trailing commas are not allowed in JSON (line 1, column 13)",
+		"{\"a\": foo}":             "unquoted string \"foo\" is not allowed in JSON (line 1, column 7)",
+		"{a: 1}":                   "unquoted keys are not allowed in JSON (line 1, column 2)",
+		"{\"a\" = 1}":              "'=' is not allowed in JSON, use ':' (line 1, column 6)",
+		"{\"a\": 1 // note\n}":     "comments are not allowed in JSON (line 1, column 9)",
+		"{\"a\": ${b}}":            "substitutions are not allowed in JSON (line 1, column 7)",
+		"{include \"other.json\"}": "includes are not allowed in JSON (line 1, column 2)",
+		"{\"a\": 1\n\"b\": 2}":     "expected ',' or '}' (line 2, column 1)",
+		"\"a\": 1":                 "a JSON document must be an object or an array (line 1, column 1)",
+		"{\"a\": \"x\ty\"}":        "control character U+0009 must be escaped in JSON (line 1, column 9)",
+		"{\"a\nb\": 1}":            "control character U+000A must be escaped in JSON (line 1, column 4)",
+	}
+
+	for text, expected := range violations {
+		err, _ := mustPanic(t, text, func() { ParseJSON(text) }).(*hocon.ParseError)
+		if err == nil || err.Error() != expected {
+			t.Errorf("%q: expected error %q, real: %v", text, expected, err)
+		}
+	}
+}
+
+func TestUnicodeWhitespace(t *testing.T) {
+	conf := ParseString("\ufeffa\u00a0=\u3000x\n\u2003b : \"\u00fc\"\nc\u00a0{ d = 1 }")
+
+	if v := conf.GetString("a"); v != "x" {
+		t.Fatalf("a, expected: x, real: %q", v)
+	}
+	if v := conf.GetString("b"); v != "ü" {
+		t.Fatalf("b, expected: ü, real: %q", v)
+	}
+	if v := conf.GetInt32("c.d"); v != 1 {
+		t.Fatalf("c.d, expected: 1, real: %d", v)
+	}
+
+	if v := ParseJSON("\ufeff{\"a\": 1}").GetInt32("a"); v != 1 {
+		t.Fatalf("a, expected: 1, real: %d", v)
+	}
+
+	err, _ := mustPanic(t, "invalid UTF-8", func() { ParseString("a = 1\nb = \"é\xff\"") }).(*hocon.ParseError)
+	if err == nil || err.Error() != "invalid UTF-8 encoding (line 2, column 7)" {
+		t.Fatalf("expected an invalid UTF-8 error, real: %v", err)
+	}
+}
+
+func TestUnicodeEscapes(t *testing.T) {
+	conf := ParseString(`"k\u00e9y" = "caf\u00e9 \ud83d\ude00 \u0041"`)
+
+	if v := conf.GetString("\"kéy\""); v != "café \U0001f600 A" {
+		t.Fatalf("key, unexpected value: %q", v)
+	}
+
+	violations := map[string]string{
+		`a = "\ud83d"`:       `lone UTF-16 surrogate in \u escape (line 1, column 6)`,
+		`a = "\ude00\ud83d"`: `lone UTF-16 surrogate in \u escape (line 1, column 6)`,
+		`a = "\ud83d\u0041"`: `invalid UTF-16 surrogate pair in \u escape (line 1, column 6)`,
+		`a = "\u12g4"`:       `malformed \u escape, expected four hex digits (line 1, column 6)`,
+		`a = "\q"`:           `unknown escape code: 'q' (line 1, column 6)`,
+	}
+
+	for text, expected := range violations {
+		err, _ := mustPanic(t, text, func() { ParseString(text) }).(*hocon.ParseError)
+		if err == nil || err.Error() != expected {
+			t.Errorf("%s: expected error %q, real: %v", text, expected, err)
+		}
+	}
+}
+
+func TestNewlineStyles(t *testing.T) {
+	conf := ParseString("a = 1,\r\nb = 2 # comment\rc = 3 // comment\r\nd = [1,\r2,\n3]\re = x")
+
+	assertValues(t, conf, map[string]string{"a": "1", "b": "2", "c": "3", "e": "x"})
+	if v := conf.GetInt32List("d"); len(v) != 3 || v[2] != 3 {
+		t.Fatalf("d, expected: [1 2 3], real: %v", v)
+	}
+
+	err, _ := mustPanic(t, "bad escape", func() { ParseString("a = 1\r\nb = 2\rc = 3\nd = \"\\x\"") }).(*hocon.ParseError)
+	if err == nil || err.Line != 4 || err.Column != 6 {
+		t.Fatalf("expected an error at line 4, column 6, real: %v", err)
+	}
+}
+
+func TestTripleQuotedText(t *testing.T) {
+	conf := ParseString(`
+a = """"foo""""
+b = """x"y""z"""
+c = """line 1
+  line 2"""
+`)
+
+	assertValues(t, conf, map[string]string{"a": `"foo"`, "b": `x"y""z`, "c": "line 1\n  line 2"})
+
+	stripped := ParseStringWithOptions(`query = """SELECT *
+    |FROM t
+    |  WHERE x = 1"""`, hocon.ParseOptions{StripMargin: true})
+	if v := stripped.GetString("query"); v != "SELECT *\nFROM t\n  WHERE x = 1" {
+		t.Fatalf("query, unexpected value: %q", v)
+	}
+
+	err, _ := mustPanic(t, "unterminated", func() { ParseString("a = 1\nb = \"\"\"never closed\n") }).(*hocon.ParseError)
+	if err == nil || err.Error() != "unterminated triple-quoted string (line 2, column 5)" {
+		t.Fatalf("expected an unterminated string error, real: %v", err)
+	}
+}
+
+func TestConcatenationWhitespace(t *testing.T) {
+	conf := ParseString(`
+a = foo "  bar  " baz
+b = " x "
+c = foo    bar   // comment
+d = ${c}" "${b}
+e = [1] [2]
+f = { x = 1 } { y = 2 }
+g = [ a b , "c" d ]
+`)
+
+	assertValues(t, conf, map[string]string{
+		"a": "foo   bar   baz",
+		"b": " x ",
+		"c": "foo    bar",
+		"d": "foo    bar  x ",
+	})
+
+	if v := conf.GetInt32List("e"); len(v) != 2 || v[1] != 2 {
+		t.Fatalf("e, expected: [1 2], real: %v", v)
+	}
+	if v := conf.GetInt32("f.x") + conf.GetInt32("f.y"); v != 3 {
+		t.Fatalf("f, expected x and y to be merged, real: %s", conf.GetNode("f"))
+	}
+	if v := conf.GetStringList("g"); strings.Join(v, "|") != "a b|c d" {
+		t.Fatalf("g, expected: [a b, c d], real: %q", v)
+	}
+}
+
+func TestNumericLiterals(t *testing.T) {
+	conf := ParseString(`
+int = 1e3
+neg = -5
+float = 2.5E-1
+quoted = "7"
+size = 10s
+big = 123456789012345678901234567890
+`)
+
+	if v := conf.GetInt64("int"); v != 1000 {
+		t.Fatalf("int, expected: 1000, real: %d", v)
+	}
+	if v := conf.GetInt32("neg"); v != -5 {
+		t.Fatalf("neg, expected: -5, real: %d", v)
+	}
+	if v := conf.GetFloat64("float"); v != 0.25 {
+		t.Fatalf("float, expected: 0.25, real: %v", v)
+	}
+	if v := conf.GetInt64("quoted"); v != 7 {
+		t.Fatalf("quoted, expected: 7, real: %d", v)
+	}
+	if !conf.GetNode("int").IsNumber() || conf.GetNode("quoted").IsNumber() || conf.GetNode("size").IsNumber() {
+		t.Fatalf("only unquoted numbers should be numbers")
+	}
+	if v := conf.GetString("size"); v != "10s" {
+		t.Fatalf("size, expected: 10s, real: %s", v)
+	}
+	if v := conf.GetBigInt("big").String(); v != "123456789012345678901234567890" {
+		t.Fatalf("big, unexpected value: %s", v)
+	}
+	if v, _ := conf.GetBigFloat("float").Float64(); v != 0.25 {
+		t.Fatalf("float, expected: 0.25, real: %v", v)
+	}
+
+	failures := map[string]func(){
+		"int32 overflow":       func() { ParseString("a = 3000000000").GetInt32("a") },
+		"int64 overflow":       func() { conf.GetInt64("big") },
+		"fraction as int":      func() { ParseString("a = 1.5").GetInt64("a") },
+		"hex":                  func() { ParseString("a = 0x10").GetInt64("a") },
+		"leading dot":          func() { ParseString("a = .5").GetFloat64("a") },
+		"misplaced underscore": func() { ParseString("a = 1__000").GetInt64("a") },
+		"underscore in float":  func() { ParseString("a = 1_000.5").GetFloat64("a") },
+		"float32 overflow":     func() { ParseString("a = 1e39").GetFloat32("a") },
+		"fraction as BigInt":   func() { ParseString("a = 1.5").GetBigInt("a") },
+	}
+
+	for name, fn := range failures {
+		mustPanic(t, name, fn)
+	}
+}
+
+func TestKeyPathExpressions(t *testing.T) {
+	conf := ParseString(`
+a."b.c".d = 1
+"x" y = 2
+foo bar baz = 3
+10.5 = 4
+"" = 5
+p { q.r = 6, q.s = 7 }
+`)
+
+	assertValues(t, conf, map[string]string{
+		`a."b.c".d`:   "1",
+		"x y":         "2",
+		"foo bar baz": "3",
+		"10.5":        "4",
+		`""`:          "5",
+		"p.q.r":       "6",
+		"p.q.s":       "7",
+	})
+
+	if keys := conf.GetNode("a").GetObject().GetKeys(); len(keys) != 1 || keys[0] != "b.c" {
+		t.Fatalf("expected a single key b.c under a, real: %q", keys)
+	}
+
+	for _, text := range []string{"a..b = 1", "a. = 1", ".a = 1"} {
+		if _, ok := mustPanic(t, text, func() { ParseString(text) }).(*hocon.ParseError); !ok {
+			t.Errorf("%q, expected a parse error", text)
+		}
+	}
+}
+
+func TestSeparators(t *testing.T) {
+	conf := ParseString(`
+a = [1, 2, 3,]
+b = [
+  1
+  2, 3
+  // comment
+  4,
+]
+c { x = 1, y = 2, }
+d { x = 1
+    y = 2 }
+e = 1, f = 2,
+`)
+
+	if v := conf.GetInt32List("a"); len(v) != 3 {
+		t.Fatalf("a, expected 3 elements, real: %v", v)
+	}
+	if v := conf.GetInt32List("b"); len(v) != 4 || v[3] != 4 {
+		t.Fatalf("b, expected: [1 2 3 4], real: %v", v)
+	}
+	if v := conf.GetInt32("c.y") + conf.GetInt32("d.y") + conf.GetInt32("f"); v != 6 {
+		t.Fatalf("expected the fields after each separator to be parsed, real: %s", conf)
+	}
+
+	failures := map[string]int{
+		"a = [1,,2]":      8,
+		"a = [,1]":        6,
+		"a { x = 1,, y }": 11,
+		"a = 1, , b = 2":  8,
+		"a = 1 b = 2":     9,
+		"a { x = 1 } b 2": 13,
+	}
+	for text, column := range failures {
+		err, ok := mustPanic(t, text, func() { ParseString(text) }).(*hocon.ParseError)
+		if !ok {
+			t.Errorf("%q, expected a parse error", text)
+		} else if err.Column != column {
+			t.Errorf("%q, expected an error at column %d, real: %s", text, column, err)
+		}
+	}
+}
+
+func TestOptionalSubstitutions(t *testing.T) {
+	conf := ParseString(`
+a = ${?missing}
+b = 1
+b = ${?missing}
+c = [1, ${?missing}, 3]
+d = foo ${?missing}bar
+e = ${?a}
+f { x = 1, y = ${?missing} }
+g = ${?b}
+`)
+
+	for _, path := range []string{"a", "e", "f.y"} {
+		if conf.HasPath(path) {
+			t.Fatalf("%s, expected the field to be removed, real: %s", path, conf.GetNode(path))
+		}
+	}
+	if keys := conf.GetNode("f").GetObject().GetKeys(); len(keys) != 1 {
+		t.Fatalf("f, expected only the key x, real: %q", keys)
+	}
+
+	if v := conf.GetInt32("b"); v != 1 {
+		t.Fatalf("b, expected the previous value 1, real: %d", v)
+	}
+	if v := conf.GetInt32List("c"); len(v) != 2 || v[0] != 1 || v[1] != 3 {
+		t.Fatalf("c, expected: [1 3], real: %v", v)
+	}
+	if v := conf.GetString("d"); v != "foo bar" {
+		t.Fatalf("d, expected: %q, real: %q", "foo bar", v)
+	}
+	if v := conf.GetInt32("g"); v != 1 {
+		t.Fatalf("g, expected: 1, real: %d", v)
+	}
+}
+
+func TestOptionalSubstitutionsInIncludes(t *testing.T) {
+	callback := func(filename string) *hocon.HoconRoot {
+		return hocon.Parse("x = ${?outer}\ny = ${?missing}", nil)
+	}
+	conf := ParseString("outer = hello\ninclude \"inc.conf\"", callback)
+
+	if v := conf.GetString("x"); v != "hello" {
+		t.Fatalf("x, expected: hello, real: %q", v)
+	}
+	if conf.HasPath("y") {
+		t.Fatalf("y, expected the field to be removed, real: %s", conf.GetNode("y"))
+	}
+}
+
+func TestWithFallbackKeepsOwnKeys(t *testing.T) {
+	conf := ParseString(`
+a = 1
+nested { x = 1 }
+`).WithFallback(ParseString(`
+b = 2
+nested { y = 2 }
+`))
+
+	if keys := conf.Root().GetObject().GetKeys(); strings.Join(keys, ",") != "a,nested,b" {
+		t.Fatalf("expected the receiver's keys followed by the fallback's, real: %q", keys)
+	}
+	if v := conf.GetInt32("a") + conf.GetInt32("b") + conf.GetInt32("nested.x") + conf.GetInt32("nested.y"); v != 6 {
+		t.Fatalf("expected values from both configs, real: %s", conf)
+	}
+}
+
+func TestExactFloats(t *testing.T) {
+	conf := ParseString(`
+tenth = 0.1
+exact = 9007199254740992
+small = 1e-300
+precise = 9007199254740993
+pi = 3.14159265358979323846
+third = 0.333333333333333333
+`)
+
+	expected := map[string]float64{
+		"tenth":   0.1,
+		"exact":   9007199254740992,
+		"small":   1e-300,
+		"precise": 9007199254740992,
+		"pi":      math.Pi,
+		"third":   1.0 / 3,
+	}
+	for path, value := range expected {
+		if v := conf.GetFloat64(path); v != value {
+			t.Fatalf("%s, expected: %v, real: %v", path, value, v)
+		}
+	}
+	if v := conf.GetFloat32("tenth"); v != 0.1 {
+		t.Fatalf("tenth, expected: 0.1, real: %v", v)
+	}
+	if v := ParseString("a = 3.14159265").GetFloat32("a"); v != math.Pi {
+		t.Fatalf("a, expected the float32 nearest to pi, real: %v", v)
+	}
+	if v := ParseString("a = 16777217").GetFloat32("a"); v != 16777216 {
+		t.Fatalf("a, expected the nearest float32 16777216, real: %v", v)
+	}
+	// numbers follow the JSON syntax, which has no leading dot
+	if _, err := ParseString("a = .5").TryGetFloat64("a"); !errors.Is(err, ErrBadValue) {
+		t.Fatalf(".5, expected ErrBadValue, real: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package configuration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EnumOptions controls how TryGetEnum matches a value against its choices.
+type EnumOptions struct {
+	// CaseSensitive turns off the default case-insensitive matching.
+	CaseSensitive bool
+}
+
+// TryGetEnum reads the value at path and returns the entry of allowed it
+// matches, spelled as in allowed. An exact match wins over the
+// case-insensitive ones. A value that matches none of them, or only
+// several of them that differ in case, is reported as ErrBadValue, with the
+// choices and the origin of the value.
+func (p *Config) TryGetEnum(path string, allowed []string, opts ...EnumOptions) (string, error) {
+	var options EnumOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	var val string
+	err := p.getText(path, func(text string) error {
+		for _, choice := range allowed {
+			if choice == text {
+				val = choice
+				return nil
+			}
+		}
+
+		var matches []string
+		if !options.CaseSensitive {
+			for _, choice := range allowed {
+				if strings.EqualFold(choice, text) {
+					matches = append(matches, choice)
+				}
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return fmt.Errorf("%q is not one of %s", text, strings.Join(allowed, ", "))
+		case 1:
+			val = matches[0]
+			return nil
+		default:
+			return fmt.Errorf("%q matches more than one of %s", text, strings.Join(matches, ", "))
+		}
+	})
+	return val, err
+}
+
+// TryGetEnumOf reads the value at path as one of the names in values and
+// returns the value it maps to, as in
+//
+//	level, err := TryGetEnumOf(conf, "akka.loglevel", map[string]Level{"INFO": Info, "DEBUG": Debug})
+func TryGetEnumOf[T ~string | ~int](p *Config, path string, values map[string]T, opts ...EnumOptions) (T, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	name, err := p.TryGetEnum(path, names, opts...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return values[name], nil
+}
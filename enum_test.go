@@ -0,0 +1,54 @@
+package configuration
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEnums(t *testing.T) {
+	conf := LoadConfig("pigeon.conf")
+	levels := []string{"OFF", "ERROR", "WARNING", "INFO", "DEBUG"}
+
+	if v, err := conf.TryGetEnum("akka.stdout-loglevel", levels); err != nil || v != "WARNING" {
+		t.Fatalf("stdout-loglevel, expected: WARNING, real: %q, %v", v, err)
+	}
+
+	conf = ParseString(`
+level = info
+bad = TRACE
+`)
+	if v, err := conf.TryGetEnum("level", levels); err != nil || v != "INFO" {
+		t.Fatalf("level, expected: INFO, real: %q, %v", v, err)
+	}
+	if _, err := conf.TryGetEnum("level", levels, EnumOptions{CaseSensitive: true}); !errors.Is(err, ErrBadValue) {
+		t.Fatalf("level, expected ErrBadValue when case sensitive, real: %v", err)
+	}
+
+	_, err := conf.TryGetEnum("bad", levels)
+	if !errors.Is(err, ErrBadValue) || !strings.Contains(err.Error(), "OFF, ERROR, WARNING, INFO, DEBUG") ||
+		!strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("bad, expected an error listing the choices and the origin, real: %v", err)
+	}
+
+	type level int
+	values := map[string]level{"ERROR": 1, "INFO": 3}
+	if v, err := TryGetEnumOf(conf, "level", values); err != nil || v != 3 {
+		t.Fatalf("level, expected: 3, real: %d, %v", v, err)
+	}
+	if _, err := TryGetEnumOf(conf, "bad", values); err == nil || !strings.Contains(err.Error(), "ERROR, INFO") {
+		t.Fatalf("bad, expected an error listing the choices, real: %v", err)
+	}
+
+	conf = ParseString(`
+exact = Info
+other = iNFO
+`)
+	values = map[string]level{"INFO": 3, "Info": 4, "info": 5}
+	if v, err := TryGetEnumOf(conf, "exact", values); err != nil || v != 4 {
+		t.Fatalf("exact, expected: 4, real: %d, %v", v, err)
+	}
+	if _, err := TryGetEnumOf(conf, "other", values); !errors.Is(err, ErrBadValue) || !strings.Contains(err.Error(), "more than one of INFO, Info, info") {
+		t.Fatalf("other, expected an ambiguous match error, real: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-akka/configuration/hocon"
+)
+
+// The kinds of error returned by the TryGet getters. Use errors.Is to tell
+// them apart.
+var (
+	ErrMissing   = errors.New("missing value")
+	ErrWrongType = errors.New("wrong value type")
+	ErrBadValue  = errors.New("bad value")
+)
+
+// ConfigError is returned by the TryGet getters. It unwraps to ErrMissing,
+// ErrWrongType or ErrBadValue.
+type ConfigError struct {
+	Kind   error
+	Path   string
+	Origin hocon.Origin
+	Err    error
+}
+
+func (p *ConfigError) Error() string {
+	msg := fmt.Sprintf("configuration: %s at %q", p.Kind, p.Path)
+	if origin := p.Origin.String(); len(origin) > 0 {
+		msg += " (" + origin + ")"
+	}
+	if p.Err != nil {
+		msg += ": " + p.Err.Error()
+	}
+	return msg
+}
+
+func (p *ConfigError) Unwrap() error {
+	return p.Kind
+}
+
+func newConfigError(kind error, path string, node *hocon.HoconValue, err error) *ConfigError {
+	e := &ConfigError{Kind: kind, Path: path, Err: err}
+	if node != nil {
+		e.Origin = node.Origin()
+	}
+	return e
+}
@@ -0,0 +1,89 @@
+package hocon
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Duration is the result of reading a duration value. Infinite is set for
+// the value infinite, in which case Duration is 0.
+type Duration struct {
+	Duration time.Duration
+	Infinite bool
+}
+
+func (p Duration) IsInfinite() bool {
+	return p.Infinite
+}
+
+func (p Duration) String() string {
+	if p.Infinite {
+		return "infinite"
+	}
+	return p.Duration.String()
+}
+
+// TryGetDuration reads the value as a signed duration such as 10s, -1.5h
+// or infinite. A number without a unit is read in defaultUnit. The result
+// is computed exactly and truncated to whole nanoseconds, and an error is
+// returned if it does not fit a time.Duration.
+func (p *HoconValue) TryGetDuration(defaultUnit time.Duration) (Duration, error) {
+	res := p.GetString()
+	if strings.ToLower(res) == "infinite" {
+		return Duration{Infinite: true}, nil
+	}
+
+	groups, matched := findStringSubmatchMap(res, durationPattern)
+	if !matched {
+		return Duration{}, fmt.Errorf("%q is not a duration", res)
+	}
+
+	unit := durationUnit(groups["unit"], defaultUnit)
+	if unit == 0 {
+		return Duration{}, fmt.Errorf("unknown duration unit in %q", res)
+	}
+
+	v, ok := new(big.Rat).SetString(strings.TrimPrefix(groups["value"], "+"))
+	if !ok {
+		return Duration{}, fmt.Errorf("%q is not a duration", res)
+	}
+
+	v.Mul(v, new(big.Rat).SetInt64(int64(unit)))
+	nanos := new(big.Int).Quo(v.Num(), v.Denom())
+	if !nanos.IsInt64() {
+		return Duration{}, fmt.Errorf("duration %q overflows time.Duration", res)
+	}
+	return Duration{Duration: time.Duration(nanos.Int64())}, nil
+}
+
+func (p *HoconValue) GetDuration(defaultUnit time.Duration) Duration {
+	val, err := p.TryGetDuration(defaultUnit)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func durationUnit(unit string, defaultUnit time.Duration) time.Duration {
+	switch unit {
+	case "":
+		return defaultUnit
+	case "nanoseconds", "nanosecond", "nanos", "nano", "ns":
+		return time.Nanosecond
+	case "microseconds", "microsecond", "micros", "micro", "us":
+		return time.Microsecond
+	case "milliseconds", "millisecond", "millis", "milli", "ms":
+		return time.Millisecond
+	case "seconds", "second", "s":
+		return time.Second
+	case "minutes", "minute", "m":
+		return time.Minute
+	case "hours", "hour", "h":
+		return time.Hour
+	case "days", "day", "d":
+		return 24 * time.Hour
+	}
+	return 0
+}
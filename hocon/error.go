@@ -0,0 +1,21 @@
+package hocon
+
+import (
+	"fmt"
+)
+
+// ParseError is raised by the parser when the input is malformed. Line and
+// Column are 1-based; Column counts characters, not bytes.
+type ParseError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p *ParseError) Error() string {
+	return fmt.Sprintf("%s (line %d, column %d)", p.Message, p.Line, p.Column)
+}
+
+func newParseError(line, column int, format string, args ...interface{}) *ParseError {
+	return &ParseError{Line: line, Column: column, Message: fmt.Sprintf(format, args...)}
+}
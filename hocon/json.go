@@ -0,0 +1,183 @@
+package hocon
+
+import (
+	"bytes"
+)
+
+func (p *Parser) parseJSONText() {
+	p.reader.pullJSONWhitespace()
+	if !p.reader.IsObjectStart() && !p.reader.IsArrayStart() {
+		panic(p.reader.errorf("a JSON document must be an object or an array"))
+	}
+
+	p.parseJSONValue(p.root)
+
+	p.reader.pullJSONWhitespace()
+	if !p.reader.EOF() {
+		panic(p.reader.errorf("unexpected content after the end of the JSON document"))
+	}
+}
+
+func (p *Parser) parseJSONValue(owner *HoconValue) {
+	p.reader.pullJSONWhitespace()
+
+	switch {
+	case p.reader.IsObjectStart():
+		p.parseJSONObject(owner)
+	case p.reader.IsArrayStart():
+		owner.AppendValue(p.parseJSONArray())
+	case p.reader.IsStartOfTripleQuotedText():
+		panic(p.reader.errorf("triple-quoted strings are not allowed in JSON"))
+	case p.reader.IsStartOfQuotedText():
+		owner.AppendValue(NewHoconQuotedLiteral(p.reader.PullQuotedText().value))
+	case p.reader.IsSubstitutionStart():
+		panic(p.reader.errorf("substitutions are not allowed in JSON"))
+	case p.reader.isJSONLiteral():
+		literal := p.reader.pullJSONLiteral()
+		if numberRegexp.MatchString(literal) {
+			owner.AppendValue(NewHoconNumberLiteral(literal))
+		} else {
+			owner.AppendValue(NewHoconLiteral(literal))
+		}
+	case p.reader.isUnquotedText():
+		panic(p.reader.errorf("unquoted strings are not allowed in JSON"))
+	case p.reader.EOF():
+		panic(p.reader.errorf("end of file reached while trying to read a JSON value"))
+	default:
+		panic(p.reader.errorf("expected a JSON value"))
+	}
+}
+
+func (p *Parser) parseJSONObject(owner *HoconValue) {
+	currentObject := p.initObject(owner)
+
+	p.reader.TakeOne()
+	p.reader.pullJSONWhitespace()
+	if p.reader.IsEndOfObject() {
+		p.reader.TakeOne()
+		return
+	}
+
+	for {
+		p.reader.pullJSONWhitespace()
+		switch {
+		case p.reader.IsStartOfQuotedText():
+		case p.reader.IsEndOfObject():
+			panic(p.reader.errorf("trailing commas are not allowed in JSON"))
+		case p.reader.IsInclude():
+			panic(p.reader.errorf("includes are not allowed in JSON"))
+		case p.reader.IsUnquotedKeyStart():
+			panic(p.reader.errorf("unquoted keys are not allowed in JSON"))
+		default:
+			panic(p.reader.errorf("expected a quoted key"))
+		}
+
+		key := p.reader.PullQuotedKey().value
+
+		p.reader.pullJSONWhitespace()
+		switch {
+		case p.reader.Matches(":"):
+			p.reader.TakeOne()
+		case p.reader.Matches("="):
+			panic(p.reader.errorf("'=' is not allowed in JSON, use ':'"))
+		case p.reader.IsPlusAssignment():
+			panic(p.reader.errorf("'+=' is not allowed in JSON"))
+		case p.reader.IsDot():
+			panic(p.reader.errorf("path expressions are not allowed as JSON keys"))
+		default:
+			panic(p.reader.errorf("expected ':' after the key"))
+		}
+
+		value := currentObject.GetOrCreateKey(key)
+		value.origin = p.origin()
+		if !value.IsObject() {
+			value.Clear()
+		}
+		p.parseJSONValue(value)
+
+		p.reader.pullJSONWhitespace()
+		switch {
+		case p.reader.IsComma():
+			p.reader.TakeOne()
+		case p.reader.IsEndOfObject():
+			p.reader.TakeOne()
+			return
+		default:
+			panic(p.reader.errorf("expected ',' or '}'"))
+		}
+	}
+}
+
+func (p *Parser) parseJSONArray() *HoconArray {
+	arr := NewHoconArray()
+
+	p.reader.TakeOne()
+	p.reader.pullJSONWhitespace()
+	if p.reader.IsArrayEnd() {
+		p.reader.TakeOne()
+		return arr
+	}
+
+	for {
+		p.reader.pullJSONWhitespace()
+		if p.reader.IsArrayEnd() {
+			panic(p.reader.errorf("trailing commas are not allowed in JSON"))
+		}
+
+		v := NewHoconValue()
+		v.origin = p.origin()
+		p.parseJSONValue(v)
+		arr.values = append(arr.values, v)
+
+		p.reader.pullJSONWhitespace()
+		switch {
+		case p.reader.IsComma():
+			p.reader.TakeOne()
+		case p.reader.IsArrayEnd():
+			p.reader.TakeOne()
+			return arr
+		default:
+			panic(p.reader.errorf("expected ',' or ']'"))
+		}
+	}
+}
+
+// pullJSONWhitespace skips the four whitespace characters JSON allows.
+func (p *HoconTokenizer) pullJSONWhitespace() {
+	for !p.EOF() {
+		switch p.Peek() {
+		case ' ', '\t', '\n', '\r':
+			p.TakeOne()
+			continue
+		}
+		break
+	}
+
+	if p.IsStartOfComment() {
+		panic(p.errorf("comments are not allowed in JSON"))
+	}
+}
+
+func isJSONLiteralChar(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '+' || c == '.' || c == '_'
+}
+
+func (p *HoconTokenizer) isJSONLiteral() bool {
+	return !p.EOF() && isJSONLiteralChar(p.Peek())
+}
+
+// pullJSONLiteral reads a number or one of true, false and null.
+func (p *HoconTokenizer) pullJSONLiteral() string {
+	line, column := p.Position()
+	buf := bytes.NewBuffer(nil)
+	for p.isJSONLiteral() {
+		buf.WriteRune(p.TakeOne())
+	}
+
+	literal := buf.String()
+	if literal != "true" && literal != "false" && literal != "null" && !numberRegexp.MatchString(literal) {
+		panic(newParseError(line, column, "unquoted string %q is not allowed in JSON", literal))
+	}
+	return literal
+}
@@ -1,13 +1,41 @@
 package hocon
 
 type HoconLiteral struct {
-	value string
+	value    string
+	isNumber bool
+	quoted   bool
 }
 
 func NewHoconLiteral(value string) *HoconLiteral {
 	return &HoconLiteral{value: value}
 }
 
+// NewHoconNumberLiteral creates a literal for a number written without
+// quotes, such as 42 or -1.5e3.
+func NewHoconNumberLiteral(value string) *HoconLiteral {
+	return &HoconLiteral{value: value, isNumber: true}
+}
+
+// NewHoconQuotedLiteral creates a literal for a quoted string, which is
+// never read as a number or as null, whatever its text.
+func NewHoconQuotedLiteral(value string) *HoconLiteral {
+	return &HoconLiteral{value: value, quoted: true}
+}
+
+// IsNull reports whether the literal is an unquoted null.
+func (p *HoconLiteral) IsNull() bool {
+	return !p.quoted && p.value == "null"
+}
+
+// IsBoolean reports whether the literal is an unquoted true or false.
+func (p *HoconLiteral) IsBoolean() bool {
+	return !p.quoted && (p.value == "true" || p.value == "false")
+}
+
+func (p *HoconLiteral) IsNumber() bool {
+	return p.isNumber
+}
+
 func (p *HoconLiteral) IsString() bool {
 	return true
 }
@@ -0,0 +1,258 @@
+package hocon
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var numberRegexp = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// maxExactExponent bounds the exponents parseNumber expands exactly, so
+// that a value like 1e999999999 cannot exhaust memory.
+const maxExactExponent = 4096
+
+// parseNumber reads text as a JSON number into an exact rational, so that
+// 1e3 and 1000.0 are both recognised as the integer 1000.
+func parseNumber(text string) (*big.Rat, error) {
+	groups := numberRegexp.FindStringSubmatch(text)
+	if groups == nil {
+		return nil, fmt.Errorf("%q is not a number", text)
+	}
+
+	if exponent := groups[3]; len(exponent) > 0 {
+		if e, err := strconv.Atoi(strings.TrimPrefix(exponent[1:], "+")); err != nil || e > maxExactExponent || e < -maxExactExponent {
+			return nil, fmt.Errorf("%q has an exponent out of range", text)
+		}
+	}
+
+	r, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a number", text)
+	}
+	return r, nil
+}
+
+// digitGroupsRegexp matches integers written with '_' between groups of
+// digits, such as 1_000_000. Every sized integer getter accepts them; the
+// float and big number getters do not.
+var digitGroupsRegexp = regexp.MustCompile(`^-?[0-9]+(_[0-9]+)+$`)
+
+func removeDigitSeparators(text string) string {
+	if digitGroupsRegexp.MatchString(text) {
+		return strings.Replace(text, "_", "", -1)
+	}
+	return text
+}
+
+func parseInteger(text string, min, max int64) (int64, error) {
+	r, err := parseNumber(removeDigitSeparators(text))
+	if err != nil {
+		return 0, err
+	}
+
+	if !r.IsInt() {
+		return 0, fmt.Errorf("%q is not an integer", text)
+	}
+
+	num := r.Num()
+	if !num.IsInt64() || num.Int64() < min || num.Int64() > max {
+		return 0, fmt.Errorf("%q is out of range [%d, %d]", text, min, max)
+	}
+	return num.Int64(), nil
+}
+
+// parseUnsigned reads text as an integer in [0, max].
+func parseUnsigned(text string, max uint64) (uint64, error) {
+	r, err := parseNumber(removeDigitSeparators(text))
+	if err != nil {
+		return 0, err
+	}
+
+	if !r.IsInt() {
+		return 0, fmt.Errorf("%q is not an integer", text)
+	}
+	if r.Sign() < 0 {
+		return 0, fmt.Errorf("%q is negative", text)
+	}
+
+	num := r.Num()
+	if !num.IsUint64() || num.Uint64() > max {
+		return 0, fmt.Errorf("%q is out of range [0, %d]", text, max)
+	}
+	return num.Uint64(), nil
+}
+
+// parseFloat reads text, which must follow the JSON number syntax (so .5
+// is rejected, write 0.5), as the float of bitSize bits nearest to it, as
+// strconv.ParseFloat does. It fails only when the value overflows.
+func parseFloat(text string, bitSize int) (float64, error) {
+	r, err := parseNumber(text)
+	if err != nil {
+		return 0, err
+	}
+
+	var f float64
+	if bitSize == 32 {
+		f32, _ := r.Float32()
+		f = float64(f32)
+	} else {
+		f, _ = r.Float64()
+	}
+
+	if math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%q overflows float%d", text, bitSize)
+	}
+	return f, nil
+}
+
+// IsNumber reports whether the value is a single unquoted number, as
+// opposed to a string that merely looks like one.
+func (p *HoconValue) IsNumber() bool {
+	if len(p.values) != 1 {
+		return false
+	}
+
+	switch v := p.values[0].(type) {
+	case *HoconLiteral:
+		return v.IsNumber()
+	case *HoconSubstitution:
+		return v.ResolvedValue != nil && v.ResolvedValue.IsNumber()
+	}
+	return false
+}
+
+// TryGetBigInt returns the value as an arbitrary precision integer, or an
+// error if the value is not an integral number.
+func (p *HoconValue) TryGetBigInt() (*big.Int, error) {
+	text := p.GetString()
+	r, err := parseNumber(text)
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsInt() {
+		return nil, fmt.Errorf("%q is not an integer", text)
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// GetBigInt returns the value as an arbitrary precision integer. It panics
+// if the value is not an integral number.
+func (p *HoconValue) GetBigInt() *big.Int {
+	val, err := p.TryGetBigInt()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// TryGetBigFloat returns the value as an arbitrary precision float, with
+// enough precision to hold every digit of integral values.
+func (p *HoconValue) TryGetBigFloat() (*big.Float, error) {
+	r, err := parseNumber(p.GetString())
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Float).SetRat(r), nil
+}
+
+func (p *HoconValue) GetBigFloat() *big.Float {
+	val, err := p.TryGetBigFloat()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *HoconValue) TryGetInt() (int, error) {
+	val, err := parseInteger(p.GetString(), math.MinInt, math.MaxInt)
+	if err != nil {
+		return 0, err
+	}
+	return int(val), nil
+}
+
+func (p *HoconValue) GetInt() int {
+	val, err := p.TryGetInt()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// tryGetUnsigned reads the value as an integer in [0, max]. Since unsigned
+// settings are mostly sizes and counts, byte sizes such as 64KiB are
+// accepted as well.
+func (p *HoconValue) tryGetUnsigned(max uint64) (uint64, error) {
+	text := p.GetString()
+	if _, err := parseNumber(removeDigitSeparators(text)); err == nil {
+		return parseUnsigned(text, max)
+	}
+
+	size, err := p.TryGetByteSize()
+	if err != nil {
+		// a byte size such as -1k is reported as such, negative here
+		if _, matched := findStringSubmatchMap(text, byteSizePattern); matched {
+			return 0, err
+		}
+		return 0, fmt.Errorf("%q is neither an integer nor a byte size", text)
+	}
+	if !size.IsUint64() || size.Uint64() > max {
+		return 0, fmt.Errorf("%q is out of range [0, %d]", text, max)
+	}
+	return size.Uint64(), nil
+}
+
+func (p *HoconValue) TryGetUint() (uint, error) {
+	val, err := p.tryGetUnsigned(math.MaxUint)
+	return uint(val), err
+}
+
+func (p *HoconValue) GetUint() uint {
+	val, err := p.TryGetUint()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *HoconValue) TryGetUint16() (uint16, error) {
+	val, err := p.tryGetUnsigned(math.MaxUint16)
+	return uint16(val), err
+}
+
+func (p *HoconValue) GetUint16() uint16 {
+	val, err := p.TryGetUint16()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *HoconValue) TryGetUint32() (uint32, error) {
+	val, err := p.tryGetUnsigned(math.MaxUint32)
+	return uint32(val), err
+}
+
+func (p *HoconValue) GetUint32() uint32 {
+	val, err := p.TryGetUint32()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *HoconValue) TryGetUint64() (uint64, error) {
+	return p.tryGetUnsigned(math.MaxUint64)
+}
+
+func (p *HoconValue) GetUint64() uint64 {
+	val, err := p.TryGetUint64()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
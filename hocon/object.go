@@ -77,6 +77,16 @@ func (p *HoconObject) GetOrCreateKey(key string) *HoconValue {
 	return child
 }
 
+func (p *HoconObject) setKey(key string, element HoconElement) *HoconValue {
+	value := NewHoconValue()
+	value.AppendValue(element)
+	if _, exist := p.items[key]; !exist {
+		p.keys = append(p.keys, key)
+	}
+	p.items[key] = value
+	return value
+}
+
 func (p *HoconObject) IsString() bool {
 	return false
 }
@@ -90,7 +100,7 @@ func (p *HoconObject) ToString(indent int) string {
 	buf := bytes.NewBuffer(nil)
 	for _, k := range p.keys {
 		key := p.quoteIfNeeded(k)
-		v := p.items[key]
+		v := p.items[k]
 		buf.WriteString(fmt.Sprintf("%s%s : %s\r\n", tmp, key, v.ToString(indent)))
 	}
 	return buf.String()
@@ -118,9 +128,12 @@ func (p *HoconObject) Merge(other *HoconObject) {
 
 func (p *HoconObject) MergeImmutable(other *HoconObject) *HoconObject {
 	thisValues := map[string]*HoconValue{}
+	for k, v := range p.items {
+		thisValues[k] = v
+	}
 	otherKeys := other.keys
 
-	var thisKeys []string
+	thisKeys := append([]string{}, p.keys...)
 
 	otherItems := other.items
 
@@ -133,12 +146,13 @@ func (p *HoconObject) MergeImmutable(other *HoconObject) *HoconObject {
 
 				mergedObject := thisValue.GetObject().MergeImmutable(otherValue.GetObject())
 				mergedValue := NewHoconValue()
+				mergedValue.origin = thisValue.origin
 
 				mergedValue.AppendValue(mergedObject)
 				thisValues[otherkey] = mergedValue
 			}
 		} else {
-			thisValues[otherkey] = &HoconValue{values: otherValue.values}
+			thisValues[otherkey] = &HoconValue{values: otherValue.values, origin: otherValue.origin}
 			thisKeys = append(thisKeys, otherkey)
 		}
 	}
@@ -147,9 +161,8 @@ func (p *HoconObject) MergeImmutable(other *HoconObject) *HoconObject {
 }
 
 func (p *HoconObject) quoteIfNeeded(text string) string {
-	if strings.IndexByte(text, ' ') >= 0 ||
-		strings.IndexByte(text, '\t') >= 0 {
-		return "\"" + text + "\""
+	if text == "include" || !canBeUnquoted(text, HoconNotInUnquotedKey) {
+		return quoteString(text)
 	}
 	return text
 }
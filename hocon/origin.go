@@ -0,0 +1,29 @@
+package hocon
+
+import (
+	"fmt"
+)
+
+// Origin describes where a value was defined. Filename is empty for text
+// parsed from memory and Line is 0 when the position is unknown.
+type Origin struct {
+	Filename string
+	Line     int
+}
+
+func (p Origin) String() string {
+	switch {
+	case len(p.Filename) > 0 && p.Line > 0:
+		return fmt.Sprintf("%s: %d", p.Filename, p.Line)
+	case len(p.Filename) > 0:
+		return p.Filename
+	case p.Line > 0:
+		return fmt.Sprintf("line %d", p.Line)
+	}
+	return ""
+}
+
+// Origin returns where the value was defined.
+func (p *HoconValue) Origin() Origin {
+	return p.origin
+}
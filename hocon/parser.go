@@ -1,57 +1,229 @@
 package hocon
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"strings"
 )
 
 type IncludeCallback func(filename string) *HoconRoot
 
+// Syntax selects the grammar accepted by the parser.
+type Syntax int
+
+const (
+	// SyntaxHOCON accepts the full HOCON grammar.
+	SyntaxHOCON Syntax = iota
+	// SyntaxJSON accepts strict JSON only: HOCON extensions such as unquoted
+	// strings, comments, '=', substitutions, includes and trailing commas
+	// are rejected with a *ParseError.
+	SyntaxJSON
+)
+
+type ParseOptions struct {
+	Syntax   Syntax
+	Callback IncludeCallback
+	// StripMargin removes leading blanks followed by '|' from every line of
+	// triple-quoted strings, so multi-line text can be indented with the
+	// surrounding config.
+	StripMargin bool
+	// Filename is recorded in the origin of every parsed value.
+	Filename string
+}
+
 type Parser struct {
 	reader   *HoconTokenizer
 	root     *HoconValue
 	callback IncludeCallback
+	filename string
 
 	substitutions []*HoconSubstitution
 }
 
 func Parse(text string, callback IncludeCallback) *HoconRoot {
-	return new(Parser).parseText(text, callback)
+	return ParseWithOptions(text, ParseOptions{Callback: callback})
+}
+
+func ParseWithOptions(text string, options ParseOptions) *HoconRoot {
+	return new(Parser).parse(NewHoconTokenizer(text), options)
 }
 
-func (p *Parser) parseText(text string, callback IncludeCallback) *HoconRoot {
-	p.callback = callback
+// ParseReader parses the document read from r. The input is tokenized as
+// it is read, so r may be a pipe or a generated stream.
+func ParseReader(r io.Reader, callback IncludeCallback) *HoconRoot {
+	return ParseReaderWithOptions(r, ParseOptions{Callback: callback})
+}
+
+func ParseReaderWithOptions(r io.Reader, options ParseOptions) *HoconRoot {
+	return new(Parser).parse(NewHoconTokenizerReader(r), options)
+}
+
+func (p *Parser) parse(reader *HoconTokenizer, options ParseOptions) *HoconRoot {
+	p.callback = options.Callback
+	p.filename = options.Filename
 	p.root = NewHoconValue()
-	p.reader = NewHoconTokenizer(text)
-	p.reader.PullWhitespaceAndComments()
-	p.parseObject(p.root, true, "")
+	p.root.origin = Origin{Filename: p.filename, Line: 1}
+	p.reader = reader
+	p.reader.stripMargin = options.StripMargin
+	p.reader.pullByteOrderMark()
 
-	root := NewHoconRoot(p.root)
+	if options.Syntax == SyntaxJSON {
+		p.reader.strictJSON = true
+		p.parseJSONText()
+		return NewHoconRoot(p.root)
+	}
 
-	cRoot := root.Value()
+	p.reader.PullWhitespaceAndComments()
+	if p.reader.IsArrayStart() {
+		p.parseRootArray()
+	} else {
+		p.parseObject(p.root, "")
+	}
 
+	resolved := map[*HoconSubstitution]bool{}
 	for _, sub := range p.substitutions {
-		res := getNode(cRoot, sub.Path)
-		if res == nil {
-			envVal, exist := os.LookupEnv(sub.OrignialPath)
-			if !exist {
-				if !sub.IsOptional {
-					panic("Unresolved substitution:" + sub.Path)
-				}
-			} else {
-				hv := NewHoconValue()
-				hv.AppendValue(NewHoconLiteral(envVal))
-				sub.ResolvedValue = hv
+		p.resolveSubstitution(sub, resolved)
+	}
+
+	return NewHoconRoot(p.root, p.substitutions...)
+}
+
+// resolveSubstitution points sub at the value its path refers to, falling
+// back to the environment. The substitutions inside that value are resolved
+// first, so that a value made only of undefined optional substitutions
+// counts as missing too.
+func (p *Parser) resolveSubstitution(sub *HoconSubstitution, resolved map[*HoconSubstitution]bool) {
+	if resolved[sub] {
+		return
+	}
+	resolved[sub] = true
+
+	res := getNode(p.root, sub.Path)
+	if res != nil {
+		for _, v := range res.values {
+			if other, ok := v.(*HoconSubstitution); ok {
+				p.resolveSubstitution(other, resolved)
 			}
-		} else {
-			sub.ResolvedValue = res
+		}
+		if isUndefined(res) {
+			res = nil
 		}
 	}
 
-	return NewHoconRoot(p.root, p.substitutions...)
+	if res != nil {
+		sub.ResolvedValue = res
+		return
+	}
+
+	envVal, exist := os.LookupEnv(sub.OrignialPath)
+	if !exist {
+		if !sub.IsOptional {
+			panic("Unresolved substitution:" + sub.Path)
+		}
+		return
+	}
+
+	hv := NewHoconValue()
+	hv.AppendValue(NewHoconLiteral(envVal))
+	sub.ResolvedValue = hv
+}
+
+// isUndefined reports whether value consists only of optional
+// substitutions that did not resolve, with no earlier definition to fall
+// back to.
+func isUndefined(value *HoconValue) bool {
+	for ; value != nil; value = value.oldValue {
+		if len(value.values) == 0 {
+			return false
+		}
+		for _, v := range value.values {
+			if sub, ok := v.(*HoconSubstitution); !ok || sub.ResolvedValue != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// pruneUndefined removes the optional substitutions that did not resolve.
+// A field set only to such substitutions takes its previous value or, if
+// it has none, is removed, as is an array element made only of them.
+func pruneUndefined(value *HoconValue) {
+	var values []HoconElement
+	for _, v := range value.values {
+		switch e := v.(type) {
+		case *HoconSubstitution:
+			if e.ResolvedValue == nil {
+				continue
+			}
+		case *HoconObject:
+			pruneUndefinedFields(e)
+		case *HoconArray:
+			pruneUndefinedElements(e)
+		}
+		values = append(values, v)
+	}
+	value.values = values
 }
 
-func (p *Parser) parseObject(owner *HoconValue, root bool, currentPath string) {
+func pruneUndefinedFields(obj *HoconObject) {
+	var keys []string
+	for _, k := range obj.keys {
+		value := obj.items[k]
+		for value != nil && isPrunable(value) {
+			value = value.oldValue
+		}
+		if value == nil {
+			delete(obj.items, k)
+			continue
+		}
+		obj.items[k] = value
+		keys = append(keys, k)
+	}
+	obj.keys = keys
+}
+
+func pruneUndefinedElements(arr *HoconArray) {
+	var values []*HoconValue
+	for _, v := range arr.values {
+		if !isPrunable(v) {
+			values = append(values, v)
+		}
+	}
+	arr.values = values
+}
+
+// isPrunable prunes value and reports whether nothing is left of it.
+func isPrunable(value *HoconValue) bool {
+	if len(value.values) == 0 {
+		return false
+	}
+	pruneUndefined(value)
+	return len(value.values) == 0
+}
+
+// origin returns the origin of a value defined at the current position.
+func (p *Parser) origin() Origin {
+	line, _ := p.reader.Position()
+	return Origin{Filename: p.filename, Line: line}
+}
+
+// parseRootArray reads a document whose root is an array. Nothing but
+// whitespace and comments may follow the closing bracket.
+func (p *Parser) parseRootArray() {
+	p.reader.PullArrayStart()
+	arr := p.ParseArray("")
+	p.root.AppendValue(&arr)
+
+	p.reader.PullWhitespaceAndComments()
+	if !p.reader.EOF() {
+		panic(p.reader.errorf("unexpected content after the root array"))
+	}
+}
+
+// initObject makes owner an object, merged with the objects it replaces.
+func (p *Parser) initObject(owner *HoconValue) *HoconObject {
 	if !owner.IsObject() {
 		owner.NewValue(NewHoconObject())
 	}
@@ -70,7 +242,11 @@ func (p *Parser) parseObject(owner *HoconValue, root bool, currentPath string) {
 		}
 	}
 
-	currentObject := owner.GetObject()
+	return owner.GetObject()
+}
+
+func (p *Parser) parseObject(owner *HoconValue, currentPath string) {
+	currentObject := p.initObject(owner)
 
 	for !p.reader.EOF() {
 		t := p.reader.PullNext()
@@ -78,6 +254,9 @@ func (p *Parser) parseObject(owner *HoconValue, root bool, currentPath string) {
 		switch t.tokenType {
 		case TokenTypeInclude:
 			included := p.callback(t.value)
+			if !included.value.IsObject() && !included.value.IsEmpty() {
+				panic(p.reader.errorf("included document %q must have an object at its root", t.value))
+			}
 			substitutions := included.substitutions
 			for _, substitution := range substitutions {
 				substitution.Path = currentPath + "." + substitution.Path
@@ -85,30 +264,36 @@ func (p *Parser) parseObject(owner *HoconValue, root bool, currentPath string) {
 			p.substitutions = append(p.substitutions, substitutions...)
 			otherObj := included.value.GetObject()
 			owner.GetObject().Merge(otherObj)
+			p.pullSeparator(p.isObjectEnd)
 		case TokenTypeEoF:
 		case TokenTypeKey:
-			value := currentObject.GetOrCreateKey(t.value)
-			nextPath := t.value
-			if len(currentPath) > 0 {
-				nextPath = currentPath + "." + t.value
-			}
-			p.parseKeyContent(value, nextPath)
-			if !root {
-				return
-			}
+			p.parseKeyPath(currentObject, t.path, currentPath)
+			p.pullSeparator(p.isObjectEnd)
 		case TokenTypeObjectEnd:
 			return
 		}
 	}
 }
 
+// parseKeyPath creates the objects along a key path such as a."b.c".d and
+// parses the content of its last element.
+func (p *Parser) parseKeyPath(currentObject *HoconObject, path []string, currentPath string) {
+	value := currentObject.GetOrCreateKey(path[0])
+	value.origin = p.origin()
+	nextPath := JoinPath(currentPath, path[0])
+
+	if len(path) > 1 {
+		p.parseKeyPath(p.initObject(value), path[1:], nextPath)
+		return
+	}
+
+	p.parseKeyContent(value, nextPath)
+}
+
 func (p *Parser) parseKeyContent(value *HoconValue, currentPath string) {
 	for !p.reader.EOF() {
 		t := p.reader.PullNext()
 		switch t.tokenType {
-		case TokenTypeDot:
-			p.parseObject(value, false, currentPath)
-			return
 		case TokenTypeAssign:
 			{
 				if !value.IsObject() {
@@ -126,7 +311,7 @@ func (p *Parser) parseKeyContent(value *HoconValue, currentPath string) {
 			p.ParseValue(value, true, currentPath)
 			return
 		case TokenTypeObjectStart:
-			p.parseObject(value, true, currentPath)
+			p.parseObject(value, currentPath)
 			return
 		}
 	}
@@ -138,25 +323,45 @@ func (p *Parser) ParseValue(owner *HoconValue, isEqualPlus bool, currentPath str
 	}
 
 	p.reader.PullWhitespaceAndComments()
-	for p.reader.isValue() {
+
+	var whitespace *Token
+	previousIsSimple := false
+
+	for first := true; p.reader.isValue(); first = false {
 		t := p.reader.PullValue()
+		isSimple := t.tokenType == TokenTypeLiteralValue || t.tokenType == TokenTypeNumber ||
+			t.tokenType == TokenTypeSubstitute
 
-		if isEqualPlus {
+		if isEqualPlus && first {
 			sub := p.ParseSubstitution(currentPath, false)
 			p.substitutions = append(p.substitutions, sub)
 			owner.AppendValue(sub)
 		}
 
+		// whitespace is part of the value only between two simple values;
+		// around objects and arrays, and at either end, it is dropped
+		if whitespace != nil && previousIsSimple && isSimple {
+			owner.AppendValue(NewHoconLiteral(whitespace.value))
+		}
+
 		switch t.tokenType {
 		case TokenTypeEoF:
 		case TokenTypeLiteralValue:
 			if owner.IsObject() {
 				owner.Clear()
 			}
-			lit := NewHoconLiteral(t.value)
-			owner.AppendValue(lit)
+			if t.quoted {
+				owner.AppendValue(NewHoconQuotedLiteral(t.value))
+			} else {
+				owner.AppendValue(NewHoconLiteral(t.value))
+			}
+		case TokenTypeNumber:
+			if owner.IsObject() {
+				owner.Clear()
+			}
+			owner.AppendValue(NewHoconNumberLiteral(t.value))
 		case TokenTypeObjectStart:
-			p.parseObject(owner, true, currentPath)
+			p.parseObject(owner, currentPath)
 		case TokenTypeArrayStart:
 			arr := p.ParseArray(currentPath)
 			owner.AppendValue(&arr)
@@ -166,20 +371,12 @@ func (p *Parser) ParseValue(owner *HoconValue, isEqualPlus bool, currentPath str
 			owner.AppendValue(sub)
 		}
 
-		if p.reader.IsSpaceOrTab() {
-			p.ParseTrailingWhitespace(owner)
+		previousIsSimple = isSimple
+		whitespace = nil
+		if p.reader.IsInlineWhitespace() {
+			whitespace = p.reader.PullInlineWhitespace()
 		}
 	}
-	p.ignoreComma()
-	p.ignoreNewline()
-}
-
-func (p *Parser) ParseTrailingWhitespace(owner *HoconValue) {
-	ws := p.reader.PullSpaceOrTab()
-	if len(ws.value) > 0 {
-		wsList := NewHoconLiteral(ws.value)
-		owner.AppendValue(wsList)
-	}
 }
 
 func (p *Parser) ParseSubstitution(value string, isOptional bool) *HoconSubstitution {
@@ -188,30 +385,53 @@ func (p *Parser) ParseSubstitution(value string, isOptional bool) *HoconSubstitu
 
 func (p *Parser) ParseArray(currentPath string) HoconArray {
 	arr := NewHoconArray()
+	p.reader.PullWhitespaceAndComments()
 	for !p.reader.EOF() && !p.reader.IsArrayEnd() {
+		if p.reader.IsComma() {
+			panic(p.reader.errorf("unexpected ',', expected an array element"))
+		}
 		v := NewHoconValue()
+		v.origin = p.origin()
 		p.ParseValue(v, false, currentPath)
 		arr.values = append(arr.values, v)
-		p.reader.PullWhitespaceAndComments()
+		p.pullSeparator(p.reader.IsArrayEnd)
+	}
+	if p.reader.EOF() {
+		panic(p.reader.errorf("end of file reached while trying to read an array"))
 	}
 	p.reader.PullArrayEnd()
 	return *arr
 }
 
-func (p *Parser) ignoreComma() {
+// pullSeparator consumes what separates two fields or array elements: a
+// single comma, one or more newlines, or both. A comma may also follow the
+// last element. isEnd reports whether the enclosing object or array ends at
+// the current position, in which case no separator is needed.
+func (p *Parser) pullSeparator(isEnd func() bool) {
+	p.reader.PullInlineWhitespace()
+	hasNewline := p.reader.IsNewline() || p.reader.IsStartOfComment()
+	p.reader.PullWhitespaceAndComments()
+
 	if p.reader.IsComma() {
 		p.reader.PullComma()
+		p.reader.PullWhitespaceAndComments()
+		if p.reader.IsComma() {
+			panic(p.reader.errorf("unexpected ',', expected a value after the previous ','"))
+		}
+		return
 	}
-}
 
-func (p *Parser) ignoreNewline() {
-	if p.reader.IsNewline() {
-		p.reader.PullNewline()
+	if !hasNewline && !isEnd() {
+		panic(p.reader.errorf("expected ',' or a newline"))
 	}
 }
 
+func (p *Parser) isObjectEnd() bool {
+	return p.reader.EOF() || p.reader.IsEndOfObject()
+}
+
 func getNode(root *HoconValue, path string) *HoconValue {
-	elements := splitDottedPathHonouringQuotes(path)
+	elements := SplitPath(path)
 	currentNode := root
 
 	if currentNode == nil {
@@ -227,16 +447,49 @@ func getNode(root *HoconValue, path string) *HoconValue {
 	return currentNode
 }
 
-func splitDottedPathHonouringQuotes(path string) []string {
-	tmp1 := strings.Split(path, "\"")
+// JoinPath appends key to a dotted path. The key is quoted when it is empty
+// or holds a dot, a quote or a backslash, the last two escaped with a
+// backslash, so that SplitPath gives it back unchanged.
+func JoinPath(path, key string) string {
+	if strings.ContainsAny(key, ".\"\\") || len(key) == 0 {
+		key = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(key) + `"`
+	}
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+// SplitPath splits a dotted path such as a."b.c".d into its keys. Inside
+// quotes, a backslash escapes the character that follows it.
+func SplitPath(path string) []string {
 	var values []string
-	for i := 0; i < len(tmp1); i++ {
-		tmp2 := strings.Split(tmp1[i], ".")
-		for j := 0; j < len(tmp2); j++ {
-			if len(tmp2[j]) > 0 {
-				values = append(values, tmp2[j])
+	buf := bytes.NewBuffer(nil)
+	inQuotes, quoted, escaped := false, false, false
+
+	for _, c := range path {
+		switch {
+		case escaped:
+			buf.WriteRune(c)
+			escaped = false
+		case c == '\\' && inQuotes:
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case c == '.' && !inQuotes:
+			if buf.Len() > 0 || quoted {
+				values = append(values, buf.String())
 			}
+			buf.Reset()
+			quoted = false
+		default:
+			buf.WriteRune(c)
 		}
 	}
+
+	if buf.Len() > 0 || quoted {
+		values = append(values, buf.String())
+	}
 	return values
 }
@@ -0,0 +1,117 @@
+package hocon
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Period is a calendar based amount of time, such as 1 week or 3 months,
+// whose length in a fixed unit depends on the date it is added to.
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// AddTo returns t moved forward by the period, as time.AddDate does.
+func (p Period) AddTo(t time.Time) time.Time {
+	return t.AddDate(p.Years, p.Months, p.Days)
+}
+
+// String renders the period in ISO-8601 form, such as P1Y2M3D.
+func (p Period) String() string {
+	if p == (Period{}) {
+		return "P0D"
+	}
+
+	text := "P"
+	if p.Years != 0 {
+		text += strconv.Itoa(p.Years) + "Y"
+	}
+	if p.Months != 0 {
+		text += strconv.Itoa(p.Months) + "M"
+	}
+	if p.Days != 0 {
+		text += strconv.Itoa(p.Days) + "D"
+	}
+	return text
+}
+
+// TryGetPeriod reads the value as a whole number of days, weeks, months or
+// years. A number without a unit is a number of days.
+func (p *HoconValue) TryGetPeriod() (Period, error) {
+	res := p.GetString()
+	groups, matched := findStringSubmatchMap(res, periodPattern)
+	if !matched {
+		return Period{}, fmt.Errorf("%q is not a period", res)
+	}
+
+	v, err := strconv.Atoi(groups["value"])
+	if err != nil {
+		return Period{}, fmt.Errorf("%q is out of range", res)
+	}
+
+	switch groups["unit"] {
+	case "w", "week", "weeks":
+		return Period{Days: v * 7}, nil
+	case "m", "mo", "month", "months":
+		return Period{Months: v}, nil
+	case "y", "year", "years":
+		return Period{Years: v}, nil
+	}
+	return Period{Days: v}, nil
+}
+
+func (p *HoconValue) GetPeriod() Period {
+	val, err := p.TryGetPeriod()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Temporal is either a fixed Duration or, when Period is not nil, a
+// calendar Period.
+type Temporal struct {
+	Duration time.Duration
+	Period   *Period
+}
+
+// AddTo returns t moved forward by the duration or the period.
+func (p Temporal) AddTo(t time.Time) time.Time {
+	if p.Period != nil {
+		return p.Period.AddTo(t)
+	}
+	return t.Add(p.Duration)
+}
+
+// TryGetTemporal reads the value as a duration and, failing that, as a
+// period. Units shared by both read as durations, so 5m is five minutes and
+// 5mo five months. A number without a unit is a number of days, as it is
+// for TryGetPeriod; Typesafe Config's getTemporal reads it as milliseconds
+// instead.
+func (p *HoconValue) TryGetTemporal() (Temporal, error) {
+	groups, unitless := findStringSubmatchMap(p.GetString(), periodPattern)
+	unitless = unitless && groups["unit"] == ""
+
+	if !unitless {
+		if d, err := p.TryGetTimeDuration(false); err == nil {
+			return Temporal{Duration: d}, nil
+		}
+	}
+
+	period, err := p.TryGetPeriod()
+	if err != nil {
+		return Temporal{}, fmt.Errorf("%q is neither a duration nor a period", p.GetString())
+	}
+	return Temporal{Period: &period}, nil
+}
+
+func (p *HoconValue) GetTemporal() Temporal {
+	val, err := p.TryGetTemporal()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
@@ -0,0 +1,208 @@
+package hocon
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// ParseProperties reads a Java .properties document and maps it into a
+// config tree as described by the HOCON spec: every key is split on '.'
+// into a path, every value is a string, a key that appears twice keeps its
+// last value, and when a path is used both as a string and as an object the
+// object wins. The input is read as UTF-8.
+func ParseProperties(r io.Reader) *HoconRoot {
+	type property struct {
+		path  []string
+		value string
+	}
+
+	var properties []*property
+	byKey := map[string]*property{}
+
+	for _, line := range readPropertiesLines(r) {
+		key, value := splitPropertiesLine(line)
+		if prop, exist := byKey[key]; exist {
+			prop.value = value
+			continue
+		}
+		prop := &property{path: strings.Split(key, "."), value: value}
+		byKey[key] = prop
+		properties = append(properties, prop)
+	}
+
+	root := NewHoconObject()
+	for _, prop := range properties {
+		obj := root
+		last := len(prop.path) - 1
+		for _, segment := range prop.path[:last] {
+			child := obj.GetKey(segment)
+			if child == nil || !child.IsObject() {
+				child = obj.setKey(segment, NewHoconObject())
+			}
+			obj = child.GetObject()
+		}
+
+		leaf := prop.path[last]
+		if existing := obj.GetKey(leaf); existing != nil && existing.IsObject() {
+			continue
+		}
+		obj.setKey(leaf, NewHoconLiteral(prop.value))
+	}
+
+	value := NewHoconValue()
+	value.AppendValue(root)
+	return NewHoconRoot(value)
+}
+
+// readPropertiesLines returns the logical lines of a properties document,
+// with comments and blank lines removed and continuation lines joined.
+func readPropertiesLines(r io.Reader) []string {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(scanPropertiesLines)
+
+	var lines []string
+	var logical *bytes.Buffer
+
+	for scanner.Scan() {
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+
+		if logical == nil {
+			if len(line) == 0 || line[0] == '#' || line[0] == '!' {
+				continue
+			}
+			logical = bytes.NewBuffer(nil)
+		}
+
+		if continues := isPropertiesContinuation(line); continues {
+			logical.WriteString(line[:len(line)-1])
+			continue
+		}
+
+		logical.WriteString(line)
+		lines = append(lines, logical.String())
+		logical = nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		panic(err)
+	}
+
+	if logical != nil {
+		lines = append(lines, logical.String())
+	}
+
+	return lines
+}
+
+// scanPropertiesLines splits on \n, \r\n and a lone \r.
+func scanPropertiesLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	for i, c := range data {
+		switch c {
+		case '\n':
+			return i + 1, data[:i], nil
+		case '\r':
+			if i+1 < len(data) {
+				if data[i+1] == '\n' {
+					return i + 2, data[:i], nil
+				}
+				return i + 1, data[:i], nil
+			}
+			if atEOF {
+				return i + 1, data[:i], nil
+			}
+			return 0, nil, nil
+		}
+	}
+
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func isPropertiesContinuation(line string) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+func splitPropertiesLine(line string) (key, value string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			end = i
+			break
+		}
+	}
+
+	rest := strings.TrimLeft(line[end:], " \t\f")
+	if len(rest) > 0 && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	return unescapeProperties(line[:end]), unescapeProperties(rest)
+}
+
+func unescapeProperties(text string) string {
+	if strings.IndexByte(text, '\\') < 0 {
+		return text
+	}
+
+	buf := bytes.NewBuffer(nil)
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c != '\\' || i+1 == len(text) {
+			buf.WriteByte(c)
+			continue
+		}
+
+		i++
+		switch text[i] {
+		case 't':
+			buf.WriteByte('\t')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'u':
+			r := parsePropertiesCodeUnit(text, i+1)
+			i += 4
+			if utf16.IsSurrogate(r) && strings.HasPrefix(text[i+1:], "\\u") {
+				if pair := utf16.DecodeRune(r, parsePropertiesCodeUnit(text, i+3)); pair != unicode.ReplacementChar {
+					r = pair
+					i += 6
+				}
+			}
+			buf.WriteRune(r)
+		default:
+			buf.WriteByte(text[i])
+		}
+	}
+	return buf.String()
+}
+
+func parsePropertiesCodeUnit(text string, start int) rune {
+	if start+4 > len(text) {
+		panic(fmt.Errorf("malformed \\uxxxx encoding in properties: %q", text))
+	}
+	code, err := strconv.ParseUint(text[start:start+4], 16, 16)
+	if err != nil {
+		panic(fmt.Errorf("malformed \\uxxxx encoding in properties: %q", text))
+	}
+	return rune(code)
+}
@@ -1,8 +1,11 @@
 package hocon
 
+import "sync"
+
 type HoconRoot struct {
 	value         *HoconValue
 	substitutions []*HoconSubstitution
+	pruneOnce     sync.Once
 }
 
 func NewHoconRoot(value *HoconValue, substitutions ...*HoconSubstitution) *HoconRoot {
@@ -12,10 +15,19 @@ func NewHoconRoot(value *HoconValue, substitutions ...*HoconSubstitution) *Hocon
 	}
 }
 
-func (p HoconRoot) Value() *HoconValue {
+// Value returns the root value. The optional substitutions that did not
+// resolve are only pruned here, and not when the document is parsed, since
+// a document that gets included can still have them resolved by the
+// including one.
+func (p *HoconRoot) Value() *HoconValue {
+	p.pruneOnce.Do(func() {
+		if p.value != nil {
+			pruneUndefined(p.value)
+		}
+	})
 	return p.value
 }
 
-func (p HoconRoot) Substitutions() []*HoconSubstitution {
+func (p *HoconRoot) Substitutions() []*HoconSubstitution {
 	return p.substitutions
 }
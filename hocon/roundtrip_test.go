@@ -0,0 +1,194 @@
+package hocon
+
+import (
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+var roundTripAlphabet = []string{
+	"a", "b", "z", "A", "Z", "0", "1", "9", "-", "_", ".", "/", "//", "#",
+	" ", "\t", "\n", "\r", "\"", "\\", "$", "{", "}", "[", "]", ":", "=",
+	"1", "2.5", "e3",
+	"+", ",", "`", "^", "?", "!", "@", "*", "&", "é", "ß", "世界", "😀",
+	"\x00", "\x01", "\x1f", "\u00a0",
+}
+
+type roundTripGenerator struct {
+	rnd *rand.Rand
+}
+
+func (p *roundTripGenerator) text(allowEmpty bool) string {
+	for {
+		n := p.rnd.Intn(8)
+		if n == 0 && !allowEmpty {
+			continue
+		}
+
+		var parts []string
+		for i := 0; i < n; i++ {
+			parts = append(parts, roundTripAlphabet[p.rnd.Intn(len(roundTripAlphabet))])
+		}
+		text := strings.Join(parts, "")
+
+		// the literal null reads back as an empty string
+		if text == "null" {
+			continue
+		}
+		return text
+	}
+}
+
+func (p *roundTripGenerator) value(depth int) *HoconValue {
+	value := NewHoconValue()
+
+	kind := p.rnd.Intn(3)
+	if depth <= 0 {
+		kind = 0
+	}
+
+	switch kind {
+	case 0:
+		switch p.rnd.Intn(8) {
+		case 0, 1:
+			numbers := []string{"0", "-1", "42", "1.5", "-0.25e-3", "6E+23"}
+			value.AppendValue(NewHoconNumberLiteral(numbers[p.rnd.Intn(len(numbers))]))
+		case 2:
+			// a boolean and the string with the same text must stay apart
+			booleans := []string{"true", "false"}
+			text := booleans[p.rnd.Intn(len(booleans))]
+			if p.rnd.Intn(2) == 0 {
+				value.AppendValue(NewHoconLiteral(text))
+			} else {
+				value.AppendValue(NewHoconQuotedLiteral(text))
+			}
+		default:
+			value.AppendValue(NewHoconLiteral(p.text(true)))
+		}
+	case 1:
+		arr := NewHoconArray()
+		for i, n := 0, p.rnd.Intn(4); i < n; i++ {
+			arr.values = append(arr.values, p.value(depth-1))
+		}
+		value.AppendValue(arr)
+	case 2:
+		value.AppendValue(p.object(depth - 1))
+	}
+
+	return value
+}
+
+func (p *roundTripGenerator) object(depth int) *HoconObject {
+	obj := NewHoconObject()
+	for i, n := 0, p.rnd.Intn(5); i < n; i++ {
+		key := p.text(true)
+		if _, exist := obj.items[key]; exist {
+			continue
+		}
+		child := p.value(depth)
+		obj.items[key] = child
+		obj.keys = append(obj.keys, key)
+	}
+	return obj
+}
+
+func equalHoconValues(a, b *HoconValue) bool {
+	switch {
+	case a.IsObject():
+		if !b.IsObject() {
+			return false
+		}
+		aObj, bObj := a.GetObject(), b.GetObject()
+		if len(aObj.GetKeys()) != len(bObj.GetKeys()) {
+			return false
+		}
+		for i, k := range aObj.GetKeys() {
+			if bObj.GetKeys()[i] != k {
+				return false
+			}
+			if !equalHoconValues(aObj.GetKey(k), bObj.GetKey(k)) {
+				return false
+			}
+		}
+		return true
+	case a.IsArray():
+		if !b.IsArray() {
+			return false
+		}
+		aArr, bArr := a.GetArray(), b.GetArray()
+		if len(aArr) != len(bArr) {
+			return false
+		}
+		for i := range aArr {
+			if !equalHoconValues(aArr[i], bArr[i]) {
+				return false
+			}
+		}
+		return true
+	case a.IsString():
+		return b.IsString() && a.IsNumber() == b.IsNumber() && a.IsBoolean() == b.IsBoolean() && a.GetString() == b.GetString()
+	}
+	return false
+}
+
+func TestRenderParseRoundTrip(t *testing.T) {
+	gen := &roundTripGenerator{rnd: rand.New(rand.NewSource(1))}
+
+	for i := 0; i < 2000; i++ {
+		expected := NewHoconValue()
+		expected.AppendValue(gen.object(3))
+
+		text := expected.String()
+		actual := Parse(text, nil).Value()
+
+		if !equalHoconValues(expected, actual) {
+			t.Fatalf("round trip mismatch, rendered:\n%s\nreparsed:\n%s", text, actual.String())
+		}
+	}
+}
+
+func TestRenderQuotesSpecialKeys(t *testing.T) {
+	obj := NewHoconObject()
+	for _, key := range []string{"a.b", "a b", "include", "", "x//y", "k#1"} {
+		obj.GetOrCreateKey(key).AppendValue(NewHoconLiteral("v"))
+	}
+	root := NewHoconValue()
+	root.AppendValue(obj)
+
+	reparsed := Parse(root.String(), nil).Value().GetObject()
+	for _, key := range obj.GetKeys() {
+		if v := reparsed.GetKey(key); v == nil || v.GetString() != "v" {
+			t.Fatalf("key %q did not survive the round trip:\n%s", key, root.String())
+		}
+	}
+}
+
+// oneByteReader hands out its input a byte at a time, like a slow pipe.
+type oneByteReader struct {
+	data []byte
+}
+
+func (p *oneByteReader) Read(b []byte) (int, error) {
+	if len(p.data) == 0 {
+		return 0, io.EOF
+	}
+	b[0] = p.data[0]
+	p.data = p.data[1:]
+	return 1, nil
+}
+
+func TestParseReaderMatchesParse(t *testing.T) {
+	gen := &roundTripGenerator{rnd: rand.New(rand.NewSource(2))}
+
+	for i := 0; i < 200; i++ {
+		expected := NewHoconValue()
+		expected.AppendValue(gen.object(3))
+		text := expected.String()
+
+		actual := ParseReader(&oneByteReader{data: []byte(text)}, nil).Value()
+		if !equalHoconValues(expected, actual) {
+			t.Fatalf("reader parse mismatch, input:\n%s\nparsed:\n%s", text, actual.String())
+		}
+	}
+}
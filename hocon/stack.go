@@ -34,3 +34,10 @@ func (p *Stack) Pop() (int, error) {
 	p.values = p.values[:l-1]
 	return res, nil
 }
+
+func (p *Stack) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return len(p.values)
+}
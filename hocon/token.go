@@ -1,5 +1,9 @@
 package hocon
 
+import (
+	"strings"
+)
+
 type TokenType int
 
 const (
@@ -19,6 +23,7 @@ const (
 	TokenTypeComma
 	TokenTypeSubstitute
 	TokenTypeInclude
+	TokenTypeNumber
 )
 
 var (
@@ -28,7 +33,9 @@ var (
 type Token struct {
 	tokenType  TokenType
 	value      string
+	path       []string
 	isOptional bool
+	quoted     bool
 }
 
 func NewToken(v interface{}) *Token {
@@ -48,7 +55,13 @@ func NewToken(v interface{}) *Token {
 }
 
 func (p *Token) Key(key string) *Token {
-	return &Token{tokenType: TokenTypeKey, value: key}
+	return &Token{tokenType: TokenTypeKey, value: key, path: []string{key}}
+}
+
+// KeyPath creates a key token for a path expression such as a."b.c".d,
+// whose elements are a, b.c and d.
+func (p *Token) KeyPath(path []string) *Token {
+	return &Token{tokenType: TokenTypeKey, value: strings.Join(path, "."), path: path}
 }
 
 func (p *Token) Substitution(path string, isOptional bool) *Token {
@@ -59,6 +72,15 @@ func (p *Token) LiteralValue(value string) *Token {
 	return &Token{tokenType: TokenTypeLiteralValue, value: value}
 }
 
+// QuotedValue creates a literal token for a quoted or triple-quoted string.
+func (p *Token) QuotedValue(value string) *Token {
+	return &Token{tokenType: TokenTypeLiteralValue, value: value, quoted: true}
+}
+
+func (p *Token) Number(value string) *Token {
+	return &Token{tokenType: TokenTypeNumber, value: value}
+}
+
 func (p *Token) Include(path string) *Token {
 	return &Token{tokenType: TokenTypeInclude, value: path}
 }
@@ -97,6 +119,8 @@ func StringTokenType(tokenType TokenType) string {
 		return "TokenTypeSubstitute"
 	case TokenTypeInclude:
 		return "TokenTypeInclude"
+	case TokenTypeNumber:
+		return "TokenTypeNumber"
 	}
 	return "<<unknown token type>>"
 }
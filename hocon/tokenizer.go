@@ -1,9 +1,15 @@
 package hocon
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
 const (
@@ -11,16 +17,51 @@ const (
 	HoconNotInUnquotedText = "$\"{}[]:=+,#`^?!@*&\\"
 )
 
+// MaxLookahead bounds how far a Tokenizer reading from an io.Reader can look
+// ahead of the oldest position saved with Push.
+const MaxLookahead = 64 * 1024
+
+// Tokenizer reads the input through a window that only holds the bytes the
+// tokenizer can still go back to. When no position is saved with Push, the
+// consumed part of the window is dropped before more input is read.
 type Tokenizer struct {
-	text       string
+	reader     *bufio.Reader
+	buf        []byte
+	offset     int
 	index      int
 	indexStack *Stack
+
+	// line and column of buf[0], and whether the byte before it is a '\r'
+	line    int
+	column  int
+	afterCR bool
+
+	// the same for the index last passed to Position
+	posIndex   int
+	posLine    int
+	posColumn  int
+	posAfterCR bool
 }
 
 func NewTokenizer(text string) *Tokenizer {
 	return &Tokenizer{
 		indexStack: NewStack(),
-		text:       text,
+		buf:        []byte(text),
+		line:       1,
+		column:     1,
+		posLine:    1,
+		posColumn:  1,
+	}
+}
+
+func NewTokenizerReader(r io.Reader) *Tokenizer {
+	return &Tokenizer{
+		indexStack: NewStack(),
+		reader:     bufio.NewReader(r),
+		line:       1,
+		column:     1,
+		posLine:    1,
+		posColumn:  1,
 	}
 }
 
@@ -36,32 +77,134 @@ func (p *Tokenizer) Pop() {
 	p.index = index
 }
 
-func (p *Tokenizer) EOF() bool {
-	return p.index >= len(p.text)
+// fill makes sure that n bytes from the current index are in the window and
+// reports whether the input is long enough.
+func (p *Tokenizer) fill(n int) bool {
+	if p.index-p.offset+n <= len(p.buf) {
+		return true
+	}
+	return p.read(n)
 }
 
-func (p *Tokenizer) Matches(pattern string) bool {
-
-	if len(pattern)+p.index > len(p.text) {
+// read is the part of fill that reads more input, kept apart so that the
+// common case of fill can be inlined.
+func (p *Tokenizer) read(n int) bool {
+	need := p.index - p.offset + n
+	if p.reader == nil {
 		return false
 	}
 
-	selected := string(p.text[p.index : p.index+len(pattern)])
+	if p.indexStack.Len() == 0 {
+		p.discard()
+		need = n
+	} else if need > MaxLookahead {
+		panic(p.errorf("lookahead of more than %d bytes", MaxLookahead))
+	}
+
+	for len(p.buf) < need {
+		p.buf = slices.Grow(p.buf, 4096)
+		read, err := p.reader.Read(p.buf[len(p.buf) : len(p.buf)+4096])
+		p.buf = p.buf[:len(p.buf)+read]
+		if err == io.EOF {
+			p.reader = nil
+			break
+		}
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return need <= len(p.buf)
+}
+
+// discard drops the consumed part of the window.
+func (p *Tokenizer) discard() {
+	consumed := p.buf[:p.index-p.offset]
+	p.line, p.column, p.afterCR = advancePosition(p.line, p.column, p.afterCR, consumed)
+	p.buf = append(p.buf[:0], p.buf[len(consumed):]...)
+	p.offset = p.index
+}
+
+// advancePosition moves a line and column past data. \n, \r\n and a lone
+// \r each end a line.
+func advancePosition(line, column int, afterCR bool, data []byte) (int, int, bool) {
+	for _, c := range data {
+		switch {
+		case c == '\r':
+			line++
+			column = 1
+		case c == '\n':
+			if !afterCR {
+				line++
+				column = 1
+			}
+		case c&0xc0 != 0x80:
+			column++
+		}
+		afterCR = c == '\r'
+	}
+	return line, column, afterCR
+}
+
+// Position returns the 1-based line and column of the current index. It
+// starts from the position it returned last, so that walking through the
+// input costs only the bytes passed since.
+func (p *Tokenizer) Position() (line, column int) {
+	if p.posIndex < p.offset || !p.retreatPosition() {
+		p.posIndex, p.posLine, p.posColumn, p.posAfterCR = p.offset, p.line, p.column, p.afterCR
+	}
+	p.posLine, p.posColumn, p.posAfterCR = advancePosition(p.posLine, p.posColumn, p.posAfterCR, p.buf[p.posIndex-p.offset:p.index-p.offset])
+	p.posIndex = p.index
+	return p.posLine, p.posColumn
+}
 
-	if selected == pattern {
+// retreatPosition moves the last position back to the current index after
+// a Pop. It reports false when a line ends in between, as the column would
+// then have to be counted again from the start of the window.
+func (p *Tokenizer) retreatPosition() bool {
+	if p.index >= p.posIndex {
 		return true
 	}
 
-	return false
+	back := p.buf[p.index-p.offset : p.posIndex-p.offset]
+	if bytes.ContainsAny(back, "\r\n") {
+		return false
+	}
+	for _, c := range back {
+		if c&0xc0 != 0x80 {
+			p.posColumn--
+		}
+	}
+	p.posIndex = p.index
+	if p.index > p.offset {
+		p.posAfterCR = p.buf[p.index-p.offset-1] == '\r'
+	} else {
+		p.posAfterCR = p.afterCR
+	}
+	return true
+}
+
+func (p *Tokenizer) errorf(format string, args ...interface{}) *ParseError {
+	line, column := p.Position()
+	return newParseError(line, column, format, args...)
+}
+
+func (p *Tokenizer) EOF() bool {
+	return !p.fill(1)
+}
+
+func (p *Tokenizer) Matches(pattern string) bool {
+	if !p.fill(len(pattern)) {
+		return false
+	}
+
+	start := p.index - p.offset
+	return string(p.buf[start:start+len(pattern)]) == pattern
 }
 
 func (p *Tokenizer) MatchesMore(patterns []string) bool {
 	for _, pattern := range patterns {
-		if len(pattern)+p.index >= len(p.text) {
-			continue
-		}
-
-		if string(p.text[p.index:p.index+len(pattern)]) == pattern {
+		if p.Matches(pattern) {
 			return true
 		}
 	}
@@ -69,31 +212,63 @@ func (p *Tokenizer) MatchesMore(patterns []string) bool {
 }
 
 func (p *Tokenizer) Take(length int) string {
-	if p.index+length > len(p.text) {
+	if !p.fill(length) {
 		return ""
 	}
 
-	str := string(p.text[p.index : p.index+length])
+	start := p.index - p.offset
+	str := string(p.buf[start : start+length])
 	p.index += length
 	return str
 }
 
-func (p *Tokenizer) Peek() byte {
-	if p.EOF() {
+// peekByte returns the byte n bytes past the current index without
+// consuming it, or 0 past the end of the input.
+func (p *Tokenizer) peekByte(n int) byte {
+	if !p.fill(n + 1) {
 		return 0
 	}
+	return p.buf[p.index-p.offset+n]
+}
 
-	return p.text[p.index]
+// Peek returns the next character without consuming it, or 0 at the end of
+// the input.
+func (p *Tokenizer) Peek() rune {
+	r, _ := p.peekRune()
+	return r
 }
 
-func (p *Tokenizer) TakeOne() byte {
-	if p.EOF() {
-		return 0
+func (p *Tokenizer) TakeOne() rune {
+	r, size := p.peekRune()
+	p.index += size
+	return r
+}
+
+func (p *Tokenizer) peekRune() (rune, int) {
+	if i := p.index - p.offset; i < len(p.buf) && p.buf[i] < utf8.RuneSelf {
+		return rune(p.buf[i]), 1
+	}
+	p.fill(utf8.UTFMax)
+
+	data := p.buf[p.index-p.offset:]
+	if len(data) == 0 {
+		return 0, 0
+	}
+	if data[0] < utf8.RuneSelf {
+		return rune(data[0]), 1
+	}
+
+	r, size := utf8.DecodeRune(data)
+	if r == utf8.RuneError && size <= 1 {
+		panic(p.errorf("invalid UTF-8 encoding"))
 	}
+	return r, size
+}
 
-	b := p.text[p.index]
-	p.index += 1
-	return b
+func (p *Tokenizer) pullByteOrderMark() {
+	if p.Peek() == '\uFEFF' {
+		p.TakeOne()
+	}
 }
 
 func (p *Tokenizer) PullWhitespace() {
@@ -104,10 +279,18 @@ func (p *Tokenizer) PullWhitespace() {
 
 type HoconTokenizer struct {
 	*Tokenizer
+	stripMargin bool
+	// strictJSON rejects unescaped control characters in quoted strings,
+	// as RFC 8259 requires.
+	strictJSON bool
 }
 
 func NewHoconTokenizer(text string) *HoconTokenizer {
-	return &HoconTokenizer{NewTokenizer(text)}
+	return &HoconTokenizer{Tokenizer: NewTokenizer(text)}
+}
+
+func NewHoconTokenizerReader(r io.Reader) *HoconTokenizer {
+	return &HoconTokenizer{Tokenizer: NewTokenizerReader(r)}
 }
 
 func (p *HoconTokenizer) PullWhitespaceAndComments() {
@@ -126,18 +309,22 @@ func (p *HoconTokenizer) PullWhitespaceAndComments() {
 func (p *HoconTokenizer) PullRestOfLine() string {
 	buf := bytes.NewBuffer(nil)
 
-	for !p.EOF() {
-		c := p.TakeOne()
-		if c == '\n' {
-			break
+	for !p.EOF() && !p.IsNewline() {
+		// copy the ASCII run in the window at once, other runes one by one
+		window := p.buf[p.index-p.offset:]
+		n := 0
+		for n < len(window) && window[n] < utf8.RuneSelf && window[n] != '\n' && window[n] != '\r' {
+			n++
 		}
-
-		if c == '\r' {
+		if n == 0 {
+			buf.WriteRune(p.TakeOne())
 			continue
 		}
-		if err := buf.WriteByte(c); err != nil {
-			panic(err)
-		}
+		buf.Write(window[:n])
+		p.index += n
+	}
+	if p.IsNewline() {
+		p.PullNewline()
 	}
 
 	return strings.TrimSpace(buf.String())
@@ -146,9 +333,7 @@ func (p *HoconTokenizer) PullRestOfLine() string {
 func (p *HoconTokenizer) PullNext() (token *Token) {
 
 	p.PullWhitespaceAndComments()
-	if p.IsDot() {
-		token = p.PullDot()
-	} else if p.IsObjectStart() {
+	if p.IsObjectStart() {
 		token = p.PullStartOfObject()
 	} else if p.IsEndOfObject() {
 		token = p.PullEndOfObject()
@@ -158,10 +343,8 @@ func (p *HoconTokenizer) PullNext() (token *Token) {
 		token = p.PullPlusAssignment()
 	} else if p.IsInclude() {
 		token = p.PullInclude()
-	} else if p.isStartOfQuotedKey() {
-		token = p.PullQuotedKey()
-	} else if p.IsUnquotedKeyStart() {
-		token = p.PullUnquotedKey()
+	} else if p.isStartOfQuotedKey() || p.IsUnquotedKeyStart() || p.IsDot() {
+		token = p.PullKey()
 	} else if p.IsArrayStart() {
 		token = p.PullArrayStart()
 	} else if p.IsArrayEnd() {
@@ -174,7 +357,7 @@ func (p *HoconTokenizer) PullNext() (token *Token) {
 		return
 	}
 
-	panic(fmt.Errorf("unknown token, offset: %d", p.index))
+	panic(p.errorf("unknown token"))
 }
 
 func (p *HoconTokenizer) isStartOfQuotedKey() bool {
@@ -210,7 +393,11 @@ func (p *HoconTokenizer) PullComma() *Token {
 }
 
 func (p *HoconTokenizer) PullNewline() *Token {
-	p.Take(2)
+	if p.Matches("\r\n") {
+		p.Take(2)
+	} else {
+		p.TakeOne()
+	}
 	return NewToken(TokenTypeNewline)
 }
 
@@ -238,8 +425,10 @@ func (p *HoconTokenizer) IsComma() bool {
 	return p.Matches(",")
 }
 
+// IsNewline reports whether the input is at \n, \r\n or a lone \r.
 func (p *HoconTokenizer) IsNewline() bool {
-	return p.Matches(`\n`)
+	c := p.peekByte(0)
+	return c == '\n' || c == '\r'
 }
 
 func (p *HoconTokenizer) IsDot() bool {
@@ -278,7 +467,7 @@ func (p *HoconTokenizer) PullComment() *Token {
 func (p *HoconTokenizer) PullUnquotedKey() *Token {
 	buf := bytes.NewBuffer(nil)
 	for !p.EOF() && p.IsUnquotedKey() {
-		if err := buf.WriteByte(p.TakeOne()); err != nil {
+		if _, err := buf.WriteRune(p.TakeOne()); err != nil {
 			panic(err)
 		}
 	}
@@ -286,12 +475,52 @@ func (p *HoconTokenizer) PullUnquotedKey() *Token {
 	return DefaultToken.Key(strings.TrimSpace(buf.String()))
 }
 
+// PullKey reads a key as a path expression: a run of quoted and unquoted
+// strings in which unquoted dots separate the path elements. Whitespace
+// inside the expression is kept, whitespace at its end is not.
+func (p *HoconTokenizer) PullKey() *Token {
+	var path []string
+	segment := bytes.NewBuffer(nil)
+	hasSegment := false
+	whitespace := ""
+
+	for {
+		switch {
+		case p.isStartOfQuotedKey():
+			segment.WriteString(whitespace)
+			segment.WriteString(p.pullQuotedString())
+			whitespace, hasSegment = "", true
+		case p.IsDot():
+			if !hasSegment {
+				panic(p.errorf("empty path element in key"))
+			}
+			segment.WriteString(whitespace)
+			path = append(path, segment.String())
+			segment.Reset()
+			whitespace, hasSegment = "", false
+			p.TakeOne()
+		case p.IsInlineWhitespace():
+			whitespace += p.PullInlineWhitespace().value
+		case p.IsUnquotedKeyStart():
+			segment.WriteString(whitespace)
+			segment.WriteRune(p.TakeOne())
+			whitespace, hasSegment = "", true
+		default:
+			if !hasSegment {
+				panic(p.errorf("empty path element in key"))
+			}
+			path = append(path, segment.String())
+			return DefaultToken.KeyPath(path)
+		}
+	}
+}
+
 func (p *HoconTokenizer) IsUnquotedKey() bool {
-	return !p.EOF() && !p.IsStartOfComment() && (strings.IndexByte(HoconNotInUnquotedKey, p.Peek()) == -1)
+	return !p.EOF() && !p.IsStartOfComment() && (strings.IndexRune(HoconNotInUnquotedKey, p.Peek()) == -1)
 }
 
 func (p *HoconTokenizer) IsUnquotedKeyStart() bool {
-	return !p.EOF() && !p.IsWhitespace() && !p.IsStartOfComment() && (strings.IndexByte(HoconNotInUnquotedKey, p.Peek()) == -1)
+	return !p.EOF() && !p.IsWhitespace() && !p.IsStartOfComment() && (strings.IndexRune(HoconNotInUnquotedKey, p.Peek()) == -1)
 }
 
 func (p *HoconTokenizer) IsWhitespace() bool {
@@ -302,55 +531,77 @@ func (p *HoconTokenizer) IsWhitespaceOrComment() bool {
 	return p.IsWhitespace() || p.IsStartOfComment()
 }
 
+// PullTripleQuotedText reads a """ string. Quotes right before the closing
+// """ belong to the string, so """"foo"""" reads as "foo".
 func (p *HoconTokenizer) PullTripleQuotedText() *Token {
+	line, column := p.Position()
 	buf := bytes.NewBuffer(nil)
 	p.Take(3)
-	for !p.EOF() && !p.Matches("\"\"\"") {
-		if err := buf.WriteByte(p.Peek()); err != nil {
+	for !p.Matches("\"\"\"") {
+		if p.EOF() {
+			panic(newParseError(line, column, "unterminated triple-quoted string"))
+		}
+		if _, err := buf.WriteRune(p.TakeOne()); err != nil {
 			panic(err)
 		}
-		p.TakeOne()
 	}
+
 	p.Take(3)
-	return DefaultToken.LiteralValue(buf.String())
+	for p.Matches("\"") {
+		buf.WriteRune(p.TakeOne())
+	}
+
+	text := buf.String()
+	if p.stripMargin {
+		text = stripMargin(text)
+	}
+	return DefaultToken.QuotedValue(text)
 }
 
 func (p *HoconTokenizer) PullQuotedText() *Token {
+	return DefaultToken.QuotedValue(p.pullQuotedString())
+}
+
+func (p *HoconTokenizer) PullQuotedKey() *Token {
+	return DefaultToken.Key(p.pullQuotedString())
+}
+
+func (p *HoconTokenizer) pullQuotedString() string {
+	line, column := p.Position()
 	buf := bytes.NewBuffer(nil)
 	p.TakeOne()
-	for !p.EOF() && !p.Matches("\"") {
+	for !p.Matches("\"") {
+		if p.EOF() {
+			panic(newParseError(line, column, "unterminated quoted string"))
+		}
 		if p.Matches("\\") {
 			if _, err := buf.WriteString(p.pullEscapeSequence()); err != nil {
 				panic(err)
 			}
 		} else {
-			if err := buf.WriteByte(p.Peek()); err != nil {
+			if p.strictJSON && p.Peek() < 0x20 {
+				panic(p.errorf("control character %U must be escaped in JSON", p.Peek()))
+			}
+			if _, err := buf.WriteRune(p.TakeOne()); err != nil {
 				panic(err)
 			}
-			p.TakeOne()
 		}
 	}
 	p.TakeOne()
-	return DefaultToken.LiteralValue(buf.String())
+	return buf.String()
 }
 
-func (p *HoconTokenizer) PullQuotedKey() *Token {
-	buf := bytes.NewBuffer(nil)
-	p.TakeOne()
-	for !p.EOF() && !p.Matches("\"") {
-		if p.Matches("\\") {
-			if _, err := buf.WriteString(p.pullEscapeSequence()); err != nil {
-				panic(err)
-			}
-		} else {
-			if err := buf.WriteByte(p.Peek()); err != nil {
-				panic(err)
-			}
-			p.TakeOne()
+// stripMargin removes, from every line of text, leading blanks followed by
+// a '|', like Scala's String.stripMargin. Lines without a margin are kept.
+func stripMargin(text string) string {
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(trimmed, "|") {
+			lines[i] = trimmed[1:]
 		}
 	}
-	p.TakeOne()
-	return DefaultToken.Key(buf.String())
+	return strings.Join(lines, "")
 }
 
 func (p *HoconTokenizer) PullInclude() *Token {
@@ -362,6 +613,7 @@ func (p *HoconTokenizer) PullInclude() *Token {
 }
 
 func (p *HoconTokenizer) pullEscapeSequence() string {
+	line, column := p.Position()
 	p.TakeOne()
 	escaped := p.TakeOne()
 	switch escaped {
@@ -382,19 +634,46 @@ func (p *HoconTokenizer) pullEscapeSequence() string {
 	case 't':
 		return ("\t")
 	case 'u':
-		utf8Code := "\\u" + strings.ToLower(p.Take(4))
-		utf8Str := ""
-		if _, err := fmt.Sscanf(utf8Code, "%s", &utf8Str); err != nil {
-			panic(err)
+		r := p.pullUnicodeCodeUnit(line, column)
+		if utf16.IsSurrogate(r) {
+			if r >= 0xdc00 || !p.Matches("\\u") {
+				panic(newParseError(line, column, "lone UTF-16 surrogate in \\u escape"))
+			}
+			p.Take(2)
+			r = utf16.DecodeRune(r, p.pullUnicodeCodeUnit(line, column))
+			if r == unicode.ReplacementChar {
+				panic(newParseError(line, column, "invalid UTF-16 surrogate pair in \\u escape"))
+			}
 		}
-		return utf8Str
+		return string(r)
 	default:
-		panic(fmt.Errorf("Unknown escape code: %v", escaped))
+		panic(newParseError(line, column, "unknown escape code: %q", escaped))
+	}
+}
+
+// pullUnicodeCodeUnit reads the four hex digits of a \u escape.
+func (p *HoconTokenizer) pullUnicodeCodeUnit(line, column int) rune {
+	var code rune
+	for i := 0; i < 4; i++ {
+		c := p.Peek()
+		switch {
+		case c >= '0' && c <= '9':
+			code = code<<4 | (c - '0')
+		case c >= 'a' && c <= 'f':
+			code = code<<4 | (c - 'a' + 10)
+		case c >= 'A' && c <= 'F':
+			code = code<<4 | (c - 'A' + 10)
+		default:
+			panic(newParseError(line, column, "malformed \\u escape, expected four hex digits"))
+		}
+		p.TakeOne()
 	}
+	return code
 }
 
 func (p *HoconTokenizer) IsStartOfComment() bool {
-	return p.MatchesMore([]string{"#", "//"})
+	c := p.peekByte(0)
+	return c == '#' || (c == '/' && p.peekByte(1) == '/')
 }
 
 func (p *HoconTokenizer) PullValue() *Token {
@@ -410,6 +689,10 @@ func (p *HoconTokenizer) PullValue() *Token {
 		return p.PullQuotedText()
 	}
 
+	if p.isNumber() {
+		return p.pullNumber()
+	}
+
 	if p.isUnquotedText() {
 		return p.pullUnquotedText()
 	}
@@ -433,24 +716,22 @@ func (p *HoconTokenizer) IsSubstitutionStart() bool {
 	return p.MatchesMore([]string{"${", "${?"})
 }
 
+// IsInclude reports whether the input is at the keyword include followed by
+// whitespace and a quoted string. Errors in the string are left to
+// PullInclude, and running past MaxLookahead is an error like any other.
 func (p *HoconTokenizer) IsInclude() bool {
-	p.Push()
-	defer func() {
-		recover()
-		p.Pop()
-	}()
-	if p.Matches("include") {
-		p.Take(len("include"))
-		if p.IsWhitespaceOrComment() {
-			p.PullWhitespaceAndComments()
-			if p.IsStartOfQuotedText() {
-				p.PullQuotedText()
-				return true
-			}
-		}
+	if !p.Matches("include") {
+		return false
 	}
 
-	return false
+	p.Push()
+	defer p.Pop()
+	p.Take(len("include"))
+	if !p.IsWhitespaceOrComment() {
+		return false
+	}
+	p.PullWhitespaceAndComments()
+	return p.IsStartOfQuotedText()
 }
 
 func (p *HoconTokenizer) pullSubstitution() *Token {
@@ -463,7 +744,7 @@ func (p *HoconTokenizer) pullSubstitution() *Token {
 	}
 
 	for !p.EOF() && p.isUnquotedText() {
-		if err := buf.WriteByte(p.TakeOne()); err != nil {
+		if _, err := buf.WriteRune(p.TakeOne()); err != nil {
 			panic(err)
 		}
 	}
@@ -490,7 +771,23 @@ func (p *HoconTokenizer) IsStartSimpleValue() bool {
 func (p *HoconTokenizer) PullSpaceOrTab() *Token {
 	buf := bytes.NewBuffer(nil)
 	for p.IsSpaceOrTab() {
-		if err := buf.WriteByte(p.TakeOne()); err != nil {
+		if _, err := buf.WriteRune(p.TakeOne()); err != nil {
+			panic(err)
+		}
+	}
+	return DefaultToken.LiteralValue(buf.String())
+}
+
+// IsInlineWhitespace reports whether the input is at whitespace other than
+// a newline.
+func (p *HoconTokenizer) IsInlineWhitespace() bool {
+	return p.IsWhitespace() && !p.IsNewline()
+}
+
+func (p *HoconTokenizer) PullInlineWhitespace() *Token {
+	buf := bytes.NewBuffer(nil)
+	for p.IsInlineWhitespace() {
+		if _, err := buf.WriteRune(p.TakeOne()); err != nil {
 			panic(err)
 		}
 	}
@@ -500,15 +797,68 @@ func (p *HoconTokenizer) PullSpaceOrTab() *Token {
 func (p *HoconTokenizer) pullUnquotedText() *Token {
 	buf := bytes.NewBuffer(nil)
 	for !p.EOF() && p.isUnquotedText() {
-		if err := buf.WriteByte(p.TakeOne()); err != nil {
+		if _, err := buf.WriteRune(p.TakeOne()); err != nil {
 			panic(err)
 		}
 	}
 	return DefaultToken.LiteralValue(buf.String())
 }
 
+func (p *HoconTokenizer) isNumber() bool {
+	if p.peekByte(0) == '-' {
+		return isDigit(p.peekByte(1))
+	}
+	return isDigit(p.peekByte(0))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// pullNumber reads the longest JSON number at the input. Text that follows
+// it without whitespace, like the s in 10s, is read as a separate token and
+// concatenated to it.
+func (p *HoconTokenizer) pullNumber() *Token {
+	buf := bytes.NewBuffer(nil)
+
+	if p.Matches("-") {
+		buf.WriteRune(p.TakeOne())
+	}
+
+	if p.Matches("0") {
+		buf.WriteRune(p.TakeOne())
+	} else {
+		p.pullDigits(buf)
+	}
+
+	if p.peekByte(0) == '.' && isDigit(p.peekByte(1)) {
+		buf.WriteRune(p.TakeOne())
+		p.pullDigits(buf)
+	}
+
+	if c := p.peekByte(0); c == 'e' || c == 'E' {
+		length := 1
+		if c = p.peekByte(1); c == '+' || c == '-' {
+			length = 2
+		}
+		if isDigit(p.peekByte(length)) {
+			buf.WriteString(p.Take(length))
+			p.pullDigits(buf)
+		}
+	}
+
+	return DefaultToken.Number(buf.String())
+}
+
+func (p *HoconTokenizer) pullDigits(buf *bytes.Buffer) {
+	for isDigit(p.peekByte(0)) {
+		buf.WriteByte(p.buf[p.index-p.offset])
+		p.index++
+	}
+}
+
 func (p *HoconTokenizer) isUnquotedText() bool {
-	return !p.EOF() && !p.IsWhitespace() && !p.IsStartOfComment() && strings.IndexByte(HoconNotInUnquotedText, p.Peek()) == -1
+	return !p.EOF() && !p.IsWhitespace() && !p.IsStartOfComment() && strings.IndexRune(HoconNotInUnquotedText, p.Peek()) == -1
 }
 
 func (p *HoconTokenizer) PullSimpleValue() *Token {
@@ -555,16 +905,14 @@ MEDIUM MATHEMATICAL SPACE (\u205F)
 and IDEOGRAPHIC SPACE (\u3000)
 Byte Order Mark (\uFEFF)
 */
-func isWhitespace(c byte) bool {
-	str := string(c)
-
-	switch str {
-	case " ", "\t", "\n", "\u000B", "\u000C",
-		"\u000D", "\u00A0", "\u1680", "\u2000",
-		"\u2001", "\u2002", "\u2003", "\u2004",
-		"\u2005", "\u2006", "\u2007", "\u2008",
-		"\u2009", "\u200A", "\u202F", "\u205F",
-		"\u2060", "\u3000", "\uFEFF":
+func isWhitespace(r rune) bool {
+	switch r {
+	case ' ', '\t', '\n', '\u000B', '\u000C',
+		'\u000D', '\u00A0', '\u1680', '\u2000',
+		'\u2001', '\u2002', '\u2003', '\u2004',
+		'\u2005', '\u2006', '\u2007', '\u2008',
+		'\u2009', '\u200A', '\u202F', '\u205F',
+		'\u2060', '\u3000', '\uFEFF':
 		return true
 	}
 	return false
@@ -1,10 +1,11 @@
 package hocon
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"math/big"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -38,9 +39,17 @@ var (
 	_YiByte = (&big.Int{}).Mul(_ZiByte, _Num1024)
 )
 
+// The patterns of the values with units, matched by findStringSubmatchMap.
+const (
+	byteSizePattern = `^(?P<value>[+-]?[0-9]+(\.[0-9]+)?)\s*(?P<unit>(B|b|byte|bytes|kB|kilobyte|kilobytes|MB|megabyte|megabytes|GB|gigabyte|gigabytes|TB|terabyte|terabytes|PB|petabyte|petabytes|EB|exabyte|exabytes|ZB|zettabyte|zettabytes|YB|yottabyte|yottabytes|K|k|Ki|KiB|kibibyte|kibibytes|M|m|Mi|MiB|mebibyte|mebibytes|G|g|Gi|GiB|gibibyte|gibibytes|T|t|Ti|TiB|tebibyte|tebibytes|P|p|Pi|PiB|pebibyte|pebibytes|E|e|Ei|EiB|exbibyte|exbibytes|Z|z|Zi|ZiB|zebibyte|zebibytes|Y|y|Yi|YiB|yobibyte|yobibytes))?$`
+	durationPattern = `^(?P<value>[+-]?[0-9]+(\.[0-9]+)?)\s*(?P<unit>(nanoseconds|nanosecond|nanos|nano|ns|microseconds|microsecond|micros|micro|us|milliseconds|millisecond|millis|milli|ms|seconds|second|s|minutes|minute|m|hours|hour|h|days|day|d))?$`
+	periodPattern   = `^(?P<value>[+-]?[0-9]+)\s*(?P<unit>(d|day|days|w|week|weeks|m|mo|month|months|y|year|years))?$`
+)
+
 type HoconValue struct {
 	values   []HoconElement
 	oldValue *HoconValue
+	origin   Origin
 }
 
 func NewHoconValue() *HoconValue {
@@ -103,80 +112,179 @@ func (p *HoconValue) topValueOfSub(v interface{}) HoconElement {
 	return v.(HoconElement)
 }
 
-func (p *HoconValue) concatString() string {
+func (p *HoconValue) rawString() string {
 	concat := ""
 	for _, v := range p.values {
 		v = p.topValueOfSub(v)
 		concat += v.GetString()
 	}
+	return concat
+}
 
-	if concat == "null" {
-		concat = ""
+func (p *HoconValue) concatString() string {
+	if p.IsNull() {
+		return ""
 	}
+	return p.rawString()
+}
 
-	return strings.TrimSpace(concat)
+// IsNull reports whether the value is the unquoted null literal, as opposed
+// to the string "null". GetString reads such a value as an empty string.
+func (p *HoconValue) IsNull() bool {
+	if len(p.values) != 1 {
+		return false
+	}
+
+	switch v := p.topValueOfSub(p.values[0]).(type) {
+	case *HoconLiteral:
+		return v.IsNull()
+	case *HoconValue:
+		return v.IsNull()
+	case *HoconSubstitution:
+		return v.ResolvedValue != nil && v.ResolvedValue.IsNull()
+	}
+	return false
 }
 
-func (p *HoconValue) GetByteSize() *big.Int {
-	res := p.GetString()
-	groups, matched := findStringSubmatchMap(res, `^(?P<value>([0-9]+(\.[0-9]+)?))\s*(?P<unit>(B|b|byte|bytes|kB|kilobyte|kilobytes|MB|megabyte|megabytes|GB|gigabyte|gigabytes|TB|terabyte|terabytes|PB|petabyte|petabytes|EB|exabyte|exabytes|ZB|zettabyte|zettabytes|YB|yottabyte|yottabytes|K|k|Ki|KiB|kibibyte|kibibytes|M|m|Mi|MiB|mebibyte|mebibytes|G|g|Gi|GiB|gibibyte|gibibytes|T|t|Ti|TiB|tebibyte|tebibytes|P|p|Pi|PiB|pebibyte|pebibytes|E|e|Ei|EiB|exbibyte|exbibytes|Z|z|Zi|ZiB|zebibyte|zebibytes|Y|y|Yi|YiB|yobibyte|yobibytes))$`)
-
-	if matched {
-		u := groups["unit"]
-		strV := groups["value"]
-
-		v := int64(parsePositiveValue(strV))
-
-		bigInt := big.NewInt(v)
-
-		switch u {
-		case "B", "b", "byte", "bytes":
-			return (&big.Int{}).Mul(bigInt, _IByte)
-		case "kB", "kilobyte", "kilobytes":
-			return (&big.Int{}).Mul(bigInt, _KByte)
-		case "MB", "megabyte", "megabytes":
-			return (&big.Int{}).Mul(bigInt, _MByte)
-		case "GB", "gigabyte", "gigabytes":
-			return (&big.Int{}).Mul(bigInt, _GByte)
-		case "TB", "terabyte", "terabytes":
-			return (&big.Int{}).Mul(bigInt, _TByte)
-		case "PB", "petabyte", "petabytes":
-			return (&big.Int{}).Mul(bigInt, _PByte)
-		case "EB", "exabyte", "exabytes":
-			return (&big.Int{}).Mul(bigInt, _EByte)
-		case "ZB", "zettabyte", "zettabytes":
-			return (&big.Int{}).Mul(bigInt, _ZByte)
-		case "YB", "yottabyte", "yottabytes":
-			return (&big.Int{}).Mul(bigInt, _YByte)
-		case "K", "k", "Ki", "KiB", "kibibyte", "kibibytes":
-			return (&big.Int{}).Mul(bigInt, _KiByte)
-		case "M", "m", "Mi", "MiB", "mebibyte", "mebibytes":
-			return (&big.Int{}).Mul(bigInt, _MiByte)
-		case "G", "g", "Gi", "GiB", "gibibyte", "gibibytes":
-			return (&big.Int{}).Mul(bigInt, _GiByte)
-		case "T", "t", "Ti", "TiB", "tebibyte", "tebibytes":
-			return (&big.Int{}).Mul(bigInt, _TiByte)
-		case "P", "p", "Pi", "PiB", "pebibyte", "pebibytes":
-			return (&big.Int{}).Mul(bigInt, _PiByte)
-		case "E", "e", "Ei", "EiB", "exbibyte", "exbibytes":
-			return (&big.Int{}).Mul(bigInt, _EiByte)
-		case "Z", "z", "Zi", "ZiB", "zebibyte", "zebibytes":
-			return (&big.Int{}).Mul(bigInt, _ZiByte)
-		case "Y", "y", "Yi", "YiB", "yobibyte", "yobibytes":
-			return (&big.Int{}).Mul(bigInt, _YiByte)
-		}
+// IsBoolean reports whether the value is an unquoted true or false literal,
+// as opposed to the strings "true" and "false".
+func (p *HoconValue) IsBoolean() bool {
+	if len(p.values) != 1 {
+		return false
 	}
 
-	panic("unknown byte size unit")
+	switch v := p.topValueOfSub(p.values[0]).(type) {
+	case *HoconLiteral:
+		return v.IsBoolean()
+	case *HoconValue:
+		return v.IsBoolean()
+	case *HoconSubstitution:
+		return v.ResolvedValue != nil && v.ResolvedValue.IsBoolean()
+	}
+	return false
+}
 
+// TryGetByteSize reads the value as a number of bytes. The number may have
+// a fraction, as in 1.5GiB, as long as the result is a whole number of
+// bytes; a number without a unit is a number of bytes.
+func (p *HoconValue) TryGetByteSize() (*big.Int, error) {
+	res := p.GetString()
+	groups, matched := findStringSubmatchMap(res, byteSizePattern)
+	if !matched {
+		return nil, fmt.Errorf("%q is not a byte size", res)
+	}
+
+	unit := byteSizeUnit(groups["unit"])
+	if unit == nil {
+		return nil, fmt.Errorf("unknown byte size unit in %q", res)
+	}
+
+	v, ok := new(big.Rat).SetString(strings.TrimPrefix(groups["value"], "+"))
+	if !ok {
+		return nil, fmt.Errorf("%q is not a byte size", res)
+	}
+	if v.Sign() < 0 {
+		return nil, fmt.Errorf("byte size %q is negative", res)
+	}
+
+	v.Mul(v, new(big.Rat).SetInt(unit))
+	if !v.IsInt() {
+		return nil, fmt.Errorf("byte size %q is not a whole number of bytes", res)
+	}
+	return new(big.Int).Set(v.Num()), nil
+}
+
+func byteSizeUnit(unit string) *big.Int {
+	switch unit {
+	case "", "B", "b", "byte", "bytes":
+		return _IByte
+	case "kB", "kilobyte", "kilobytes":
+		return _KByte
+	case "MB", "megabyte", "megabytes":
+		return _MByte
+	case "GB", "gigabyte", "gigabytes":
+		return _GByte
+	case "TB", "terabyte", "terabytes":
+		return _TByte
+	case "PB", "petabyte", "petabytes":
+		return _PByte
+	case "EB", "exabyte", "exabytes":
+		return _EByte
+	case "ZB", "zettabyte", "zettabytes":
+		return _ZByte
+	case "YB", "yottabyte", "yottabytes":
+		return _YByte
+	case "K", "k", "Ki", "KiB", "kibibyte", "kibibytes":
+		return _KiByte
+	case "M", "m", "Mi", "MiB", "mebibyte", "mebibytes":
+		return _MiByte
+	case "G", "g", "Gi", "GiB", "gibibyte", "gibibytes":
+		return _GiByte
+	case "T", "t", "Ti", "TiB", "tebibyte", "tebibytes":
+		return _TiByte
+	case "P", "p", "Pi", "PiB", "pebibyte", "pebibytes":
+		return _PiByte
+	case "E", "e", "Ei", "EiB", "exbibyte", "exbibytes":
+		return _EiByte
+	case "Z", "z", "Zi", "ZiB", "zebibyte", "zebibytes":
+		return _ZiByte
+	case "Y", "y", "Yi", "YiB", "yobibyte", "yobibytes":
+		return _YiByte
+	}
 	return nil
 }
 
+func (p *HoconValue) GetByteSize() *big.Int {
+	val, err := p.TryGetByteSize()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// TryGetBytesInt64 returns the byte size as an int64, or an error if it
+// does not fit.
+func (p *HoconValue) TryGetBytesInt64() (int64, error) {
+	size, err := p.TryGetByteSize()
+	if err != nil {
+		return 0, err
+	}
+	if !size.IsInt64() {
+		return 0, fmt.Errorf("byte size %q overflows int64", p.GetString())
+	}
+	return size.Int64(), nil
+}
+
+func (p *HoconValue) GetBytesInt64() int64 {
+	val, err := p.TryGetBytesInt64()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *HoconValue) GetByteSizeList() []*big.Int {
+	arrs := p.GetArray()
+	var items []*big.Int
+	for _, v := range arrs {
+		items = append(items, v.GetByteSize())
+	}
+	return items
+}
+
 func (p *HoconValue) String() string {
 	return p.ToString(0)
 }
 
 func (p *HoconValue) ToString(indent int) string {
+	if p.IsNumber() || p.IsBoolean() {
+		return p.GetString()
+	}
+
+	if p.IsNull() {
+		return "null"
+	}
+
 	if p.IsString() {
 		return p.quoteIfNeeded(p.GetString())
 	}
@@ -242,18 +350,26 @@ func (p *HoconValue) NewValue(value HoconElement) {
 	p.values = append(p.values, value)
 }
 
-func (p *HoconValue) GetBoolean() bool {
+func (p *HoconValue) TryGetBoolean() (bool, error) {
 	v := strings.ToLower(p.GetString())
 	switch v {
 	case "on", "true", "yes":
-		return true
+		return true, nil
 	case "off", "false", "no":
-		return false
+		return false, nil
 	default:
-		panic("Unknown boolean format: " + v)
+		return false, fmt.Errorf("unknown boolean format: %q", v)
 	}
 }
 
+func (p *HoconValue) GetBoolean() bool {
+	val, err := p.TryGetBoolean()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
 func (p *HoconValue) GetString() string {
 	if p.IsString() {
 		return p.concatString()
@@ -261,44 +377,76 @@ func (p *HoconValue) GetString() string {
 	return ""
 }
 
+func (p *HoconValue) TryGetFloat64() (float64, error) {
+	return parseFloat(p.GetString(), 64)
+}
+
 func (p *HoconValue) GetFloat64() float64 {
-	val, err := strconv.ParseFloat(p.GetString(), 64)
+	val, err := p.TryGetFloat64()
 	if err != nil {
 		panic(err)
 	}
 	return val
 }
 
+func (p *HoconValue) TryGetFloat32() (float32, error) {
+	val, err := parseFloat(p.GetString(), 32)
+	if err != nil {
+		return 0, err
+	}
+	return float32(val), nil
+}
+
 func (p *HoconValue) GetFloat32() float32 {
-	val, err := strconv.ParseFloat(p.GetString(), 32)
+	val, err := p.TryGetFloat32()
 	if err != nil {
 		panic(err)
 	}
-	return float32(val)
+	return val
+}
+
+func (p *HoconValue) TryGetInt64() (int64, error) {
+	return parseInteger(p.GetString(), math.MinInt64, math.MaxInt64)
 }
 
 func (p *HoconValue) GetInt64() int64 {
-	val, err := strconv.ParseInt(p.GetString(), 10, 64)
+	val, err := p.TryGetInt64()
 	if err != nil {
 		panic(err)
 	}
 	return val
 }
 
+func (p *HoconValue) TryGetInt32() (int32, error) {
+	val, err := parseInteger(p.GetString(), math.MinInt32, math.MaxInt32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(val), nil
+}
+
 func (p *HoconValue) GetInt32() int32 {
-	val, err := strconv.ParseInt(p.GetString(), 10, 32)
+	val, err := p.TryGetInt32()
 	if err != nil {
 		panic(err)
 	}
-	return int32(val)
+	return val
+}
+
+func (p *HoconValue) TryGetByte() (byte, error) {
+	val, err := parseInteger(p.GetString(), 0, math.MaxUint8)
+	if err != nil {
+		return 0, err
+	}
+	return byte(val), nil
 }
 
 func (p *HoconValue) GetByte() byte {
-	val, err := strconv.ParseUint(p.GetString(), 10, 8)
+	val, err := p.TryGetByte()
 	if err != nil {
 		panic(err)
 	}
-	return byte(val)
+	return val
 }
 
 func (p *HoconValue) GetByteList() []byte {
@@ -390,57 +538,101 @@ func (p *HoconValue) GetChildObject(key string) *HoconValue {
 }
 
 func (p *HoconValue) IsArray() bool {
-	return p.GetArray() != nil
-}
-
-func (p *HoconValue) GetTimeDuration(allowInfinite bool) time.Duration {
-	res := p.GetString()
-	groups, matched := findStringSubmatchMap(res, `^(?P<value>([0-9]+(\.[0-9]+)?))\s*(?P<unit>(nanoseconds|nanosecond|nanos|nano|ns|microseconds|microsecond|micros|micro|us|milliseconds|millisecond|millis|milli|ms|seconds|second|s|minutes|minute|m|hours|hour|h|days|day|d))$`)
-
-	if matched {
-		u := groups["unit"]
-		strV := groups["value"]
-		v := parsePositiveValue(strV)
-
-		switch u {
-		case "nanoseconds", "nanosecond", "nanos", "nano", "ns":
-			return time.Duration(float64(time.Nanosecond) * v)
-		case "microseconds", "microsecond", "micros", "micro":
-			return time.Duration(float64(time.Microsecond) * v)
-		case "milliseconds", "millisecond", "millis", "milli", "ms":
-			return time.Duration(float64(time.Millisecond) * v)
-		case "seconds", "second", "s":
-			return time.Duration(float64(time.Second) * v)
-		case "minutes", "minute", "m":
-			return time.Duration(float64(time.Minute) * v)
-		case "hours", "hour", "h":
-			return time.Duration(float64(time.Hour) * v)
-		case "days", "day", "d":
-			return time.Duration(float64(time.Hour*24) * v)
+	for _, v := range p.values {
+		if p.topValueOfSub(v).IsArray() {
+			return true
 		}
 	}
+	return false
+}
 
-	if strings.ToLower(res) == "infinite" {
+// TryGetTimeDuration reads the value as a duration in milliseconds by
+// default. Infinite is returned as -1, or is an error if not allowed; use
+// TryGetDuration to tell it apart from real durations.
+func (p *HoconValue) TryGetTimeDuration(allowInfinite bool) (time.Duration, error) {
+	d, err := p.TryGetDuration(time.Millisecond)
+	if err != nil {
+		return 0, err
+	}
+	if d.Infinite {
 		if allowInfinite {
-			return time.Duration(-1)
+			return time.Duration(-1), nil
 		}
-		panic("infinite time duration not allowed")
+		return 0, fmt.Errorf("infinite time duration not allowed")
+	}
+	return d.Duration, nil
+}
+
+func (p *HoconValue) GetTimeDuration(allowInfinite bool) time.Duration {
+	val, err := p.TryGetTimeDuration(allowInfinite)
+	if err != nil {
+		panic(err)
 	}
+	return val
+}
 
-	return time.Duration(float64(time.Millisecond) * parsePositiveValue(res))
+func (p *HoconValue) GetTimeDurationList(allowInfinite bool) []time.Duration {
+	arrs := p.GetArray()
+	var items []time.Duration
+	for _, v := range arrs {
+		items = append(items, v.GetTimeDuration(allowInfinite))
+	}
+	return items
 }
 
 func (p *HoconValue) quoteIfNeeded(text string) string {
-	if len(text) == 0 {
-		return "\"\""
+	if !canBeUnquoted(text, HoconNotInUnquotedText) || numberRegexp.MatchString(text) || text == "null" || text == "true" || text == "false" {
+		return quoteString(text)
 	}
+	return text
+}
 
-	if strings.IndexByte(text, ' ') >= 0 ||
-		strings.IndexByte(text, '\t') >= 0 {
-		return "\"" + text + "\""
+// canBeUnquoted reports whether text reads back as itself when rendered
+// without quotes. Only printable ASCII is left unquoted so that the output
+// never depends on how the tokenizer treats other characters.
+func canBeUnquoted(text string, notAllowed string) bool {
+	if len(text) == 0 || strings.Contains(text, "//") {
+		return false
 	}
 
-	return text
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c <= ' ' || c > '~' || strings.IndexByte(notAllowed, c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func quoteString(text string) string {
+	buf := bytes.NewBuffer(nil)
+	buf.WriteByte('"')
+	for _, r := range text {
+		switch r {
+		case '"':
+			buf.WriteString("\\\"")
+		case '\\':
+			buf.WriteString("\\\\")
+		case '\b':
+			buf.WriteString("\\b")
+		case '\f':
+			buf.WriteString("\\f")
+		case '\n':
+			buf.WriteString("\\n")
+		case '\r':
+			buf.WriteString("\\r")
+		case '\t':
+			buf.WriteString("\\t")
+		default:
+			if r < ' ' {
+				fmt.Fprintf(buf, "\\u%04x", r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
 }
 
 func findStringSubmatchMap(s, exp string) (map[string]string, bool) {
@@ -460,14 +652,3 @@ func findStringSubmatchMap(s, exp string) (map[string]string, bool) {
 	}
 	return captures, true
 }
-
-func parsePositiveValue(v string) float64 {
-	value, err := strconv.ParseFloat(v, 64)
-	if err != nil {
-		panic(err)
-	}
-	if value < 0 {
-		panic("Expected a positive value instead of " + v)
-	}
-	return value
-}
@@ -0,0 +1,131 @@
+package configuration
+
+import (
+	"fmt"
+
+	"github.com/go-akka/configuration/hocon"
+)
+
+// The integer getters below reject values that do not fit the type, with a
+// *ConfigError naming the path, or path[index] for list elements. Integers
+// may be written with '_' between groups of digits, as in 1_000_000, and
+// the unsigned getters also accept byte sizes such as 64KiB.
+
+// getList returns the elements of the array at path, or nil if the path is
+// missing or set to null.
+func (p *Config) getList(path string) []*hocon.HoconValue {
+	obj := p.getValue(path)
+	if obj == nil {
+		return nil
+	}
+	return obj.GetArray()
+}
+
+func elementPath(path string, index int) string {
+	return fmt.Sprintf("%s[%d]", path, index)
+}
+
+// integer lists the types the generic helpers below are used with.
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// tryGetInteger, getInteger and getIntegerList implement the TryGet, Get and
+// GetList getters of each integer type, which differ only in convert.
+func tryGetInteger[T integer](p *Config, path string, convert func(*hocon.HoconValue) (T, error)) (T, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return 0, err
+	}
+	val, err := convert(node)
+	if err != nil {
+		return 0, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+func getInteger[T integer](p *Config, path string, convert func(*hocon.HoconValue) (T, error), defaultVal []T) T {
+	if p.getValue(path) == nil {
+		if len(defaultVal) > 0 {
+			return defaultVal[0]
+		}
+		return 0
+	}
+	val, err := tryGetInteger(p, path, convert)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func getIntegerList[T integer](p *Config, path string, convert func(*hocon.HoconValue) (T, error)) []T {
+	var items []T
+	for i, v := range p.getList(path) {
+		item, err := convert(v)
+		if err != nil {
+			panic(newConfigError(ErrBadValue, elementPath(path, i), v, err))
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (p *Config) TryGetInt(path string) (int, error) {
+	return tryGetInteger(p, path, (*hocon.HoconValue).TryGetInt)
+}
+
+func (p *Config) GetInt(path string, defaultVal ...int) int {
+	return getInteger(p, path, (*hocon.HoconValue).TryGetInt, defaultVal)
+}
+
+func (p *Config) GetIntList(path string) []int {
+	return getIntegerList(p, path, (*hocon.HoconValue).TryGetInt)
+}
+
+func (p *Config) TryGetUint(path string) (uint, error) {
+	return tryGetInteger(p, path, (*hocon.HoconValue).TryGetUint)
+}
+
+func (p *Config) GetUint(path string, defaultVal ...uint) uint {
+	return getInteger(p, path, (*hocon.HoconValue).TryGetUint, defaultVal)
+}
+
+func (p *Config) GetUintList(path string) []uint {
+	return getIntegerList(p, path, (*hocon.HoconValue).TryGetUint)
+}
+
+func (p *Config) TryGetUint16(path string) (uint16, error) {
+	return tryGetInteger(p, path, (*hocon.HoconValue).TryGetUint16)
+}
+
+func (p *Config) GetUint16(path string, defaultVal ...uint16) uint16 {
+	return getInteger(p, path, (*hocon.HoconValue).TryGetUint16, defaultVal)
+}
+
+func (p *Config) GetUint16List(path string) []uint16 {
+	return getIntegerList(p, path, (*hocon.HoconValue).TryGetUint16)
+}
+
+func (p *Config) TryGetUint32(path string) (uint32, error) {
+	return tryGetInteger(p, path, (*hocon.HoconValue).TryGetUint32)
+}
+
+func (p *Config) GetUint32(path string, defaultVal ...uint32) uint32 {
+	return getInteger(p, path, (*hocon.HoconValue).TryGetUint32, defaultVal)
+}
+
+func (p *Config) GetUint32List(path string) []uint32 {
+	return getIntegerList(p, path, (*hocon.HoconValue).TryGetUint32)
+}
+
+func (p *Config) TryGetUint64(path string) (uint64, error) {
+	return tryGetInteger(p, path, (*hocon.HoconValue).TryGetUint64)
+}
+
+func (p *Config) GetUint64(path string, defaultVal ...uint64) uint64 {
+	return getInteger(p, path, (*hocon.HoconValue).TryGetUint64, defaultVal)
+}
+
+func (p *Config) GetUint64List(path string) []uint64 {
+	return getIntegerList(p, path, (*hocon.HoconValue).TryGetUint64)
+}
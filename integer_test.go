@@ -0,0 +1,76 @@
+package configuration
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSizedIntegers(t *testing.T) {
+	conf := ParseString(`
+port = 8080
+workers = 1_000
+grouped = -2_147_483_648
+small = 2_55
+buffer = 64KiB
+negative-size = -1k
+negative = -1
+big = 70000
+ports = [80, 443, 70000]
+max = 18446744073709551615
+`)
+
+	if v := conf.GetUint16("port"); v != 8080 {
+		t.Fatalf("port, expected: 8080, real: %d", v)
+	}
+	if v := conf.GetInt("workers"); v != 1000 {
+		t.Fatalf("workers, expected: 1000, real: %d", v)
+	}
+	if v := conf.GetInt32("grouped"); v != math.MinInt32 {
+		t.Fatalf("grouped, expected: %d, real: %d", math.MinInt32, v)
+	}
+	if v := conf.GetInt64("grouped"); v != math.MinInt32 {
+		t.Fatalf("grouped, expected: %d, real: %d", math.MinInt32, v)
+	}
+	if v := conf.GetNode("small").GetByte(); v != 255 {
+		t.Fatalf("small, expected: 255, real: %d", v)
+	}
+	for name, v := range map[string]uint64{
+		"uint":   uint64(conf.GetUint("workers")),
+		"uint16": uint64(conf.GetUint16("workers")),
+		"uint32": uint64(conf.GetUint32("workers")),
+		"uint64": conf.GetUint64("workers"),
+	} {
+		if v != 1000 {
+			t.Fatalf("workers as %s, expected: 1000, real: %d", name, v)
+		}
+	}
+	if _, err := conf.TryGetUint32("negative-size"); err == nil || !strings.Contains(err.Error(), "negative") {
+		t.Fatalf("negative-size, expected a negative value error, real: %v", err)
+	}
+	if v := conf.GetUint32("buffer"); v != 65536 {
+		t.Fatalf("buffer, expected: 65536, real: %d", v)
+	}
+	if v := conf.GetUint64("max"); v != 18446744073709551615 {
+		t.Fatalf("max, expected: 18446744073709551615, real: %d", v)
+	}
+	if v := conf.GetUint("missing", 3); v != 3 {
+		t.Fatalf("missing, expected the default, real: %d", v)
+	}
+
+	for _, path := range []string{"negative", "big"} {
+		if _, err := conf.TryGetUint16(path); !errors.Is(err, ErrBadValue) {
+			t.Fatalf("%s, expected ErrBadValue, real: %v", path, err)
+		}
+	}
+
+	if v := conf.GetIntList("ports"); len(v) != 3 || v[2] != 70000 {
+		t.Fatalf("ports, expected: [80 443 70000], real: %v", v)
+	}
+
+	err, ok := mustPanic(t, "ports", func() { conf.GetUint16List("ports") }).(*ConfigError)
+	if !ok || err.Path != "ports[2]" {
+		t.Fatalf("expected an error for ports[2], real: %v", err)
+	}
+}
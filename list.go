@@ -0,0 +1,132 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-akka/configuration/hocon"
+)
+
+// ConfigList is a document whose root is an array, such as a JSON file
+// holding [{ ... }, { ... }].
+type ConfigList struct {
+	root *hocon.HoconValue
+}
+
+// IsList reports whether the root of the config is an array.
+func (p *Config) IsList() bool {
+	return p != nil && p.root != nil && p.root.IsArray()
+}
+
+// List returns the root of the config as a list. It panics if the root is
+// not an array.
+func (p *Config) List() *ConfigList {
+	if !p.IsList() {
+		panic("The root value is not an array.")
+	}
+	return &ConfigList{root: p.root}
+}
+
+func (p *ConfigList) Len() int {
+	return len(p.root.GetArray())
+}
+
+func (p *ConfigList) Values() []*hocon.HoconValue {
+	return p.root.GetArray()
+}
+
+func (p *ConfigList) Get(index int) *hocon.HoconValue {
+	return p.root.GetArray()[index]
+}
+
+// GetConfig returns the element at index as a Config, or nil if the element
+// is not an object.
+func (p *ConfigList) GetConfig(index int) *Config {
+	value := p.Get(index)
+	if !value.IsObject() {
+		return nil
+	}
+	return NewConfigFromRoot(hocon.NewHoconRoot(value))
+}
+
+func (p *ConfigList) String() string {
+	return p.root.String()
+}
+
+// getObjectList returns the elements of the array at path, or nil if the
+// path is missing. It panics with a *ConfigError if the value is not an
+// array or an element is not an object.
+func (p *Config) getObjectList(path string) []*hocon.HoconValue {
+	node := p.getValue(path)
+	if node == nil {
+		return nil
+	}
+	if !node.IsArray() {
+		panic(newConfigError(ErrWrongType, path, node, fmt.Errorf("expected a list of objects")))
+	}
+
+	values := node.GetArray()
+	for i, v := range values {
+		if !v.IsObject() {
+			panic(newConfigError(ErrWrongType, elementPath(path, i), v, fmt.Errorf("expected an object")))
+		}
+	}
+	return values
+}
+
+// GetConfigList reads an array of objects, such as
+// deployment = [{ name = a }, { name = b }], as one Config per element. It
+// panics with a *ConfigError if the value is not such an array.
+func (p *Config) GetConfigList(path string) []*Config {
+	var items []*Config
+	for _, v := range p.getObjectList(path) {
+		items = append(items, NewConfigFromRoot(hocon.NewHoconRoot(v)))
+	}
+	return items
+}
+
+// GetObjectList is GetConfigList returning the objects themselves.
+func (p *Config) GetObjectList(path string) []*hocon.HoconObject {
+	var items []*hocon.HoconObject
+	for _, v := range p.getObjectList(path) {
+		items = append(items, v.GetObject())
+	}
+	return items
+}
+
+// GetStringMap returns the fields of the object at path, each as a Config
+// with the same fallbacks as GetConfig, so that nested objects merge with
+// the fallback. A scalar field is read from its Config with an empty path,
+// as in GetStringMap("servers")["a"].GetString("").
+func (p *Config) GetStringMap(path string) map[string]*Config {
+	conf := p.GetConfig(path)
+	if conf == nil || !conf.root.IsObject() {
+		return nil
+	}
+
+	items := map[string]*Config{}
+	for _, k := range conf.root.GetObject().GetKeys() {
+		items[k] = conf.GetConfig(hocon.JoinPath("", k))
+	}
+	return items
+}
+
+// GetStringMapString returns the fields of the object at path as strings.
+// It panics with a *ConfigError if a field is an object or an array.
+func (p *Config) GetStringMapString(path string) map[string]string {
+	conf := p.GetConfig(path)
+	if conf == nil || !conf.root.IsObject() {
+		return nil
+	}
+
+	items := map[string]string{}
+	for _, k := range conf.root.GetObject().GetKeys() {
+		val, err := conf.TryGetString(hocon.JoinPath("", k))
+		if err != nil && !errors.Is(err, ErrMissing) {
+			err.(*ConfigError).Path = hocon.JoinPath(path, k)
+			panic(err)
+		}
+		items[k] = val
+	}
+	return items
+}
@@ -0,0 +1,99 @@
+package configuration
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRootArray(t *testing.T) {
+	for _, conf := range []*Config{
+		ParseString("// servers\n[\n  { name = a, port = 1 }\n  { name = b, port = 2 }\n]\n"),
+		ParseJSON(`[{"name": "a", "port": 1}, {"name": "b", "port": 2}]`),
+	} {
+		if !conf.IsList() {
+			t.Fatalf("expected an array root, real: %s", conf)
+		}
+		list := conf.List()
+		if list.Len() != 2 {
+			t.Fatalf("expected 2 elements, real: %s", list)
+		}
+		if v := list.GetConfig(1).GetString("name"); v != "b" {
+			t.Fatalf("expected: b, real: %q", v)
+		}
+		if v := list.GetConfig(0).GetInt32("port"); v != 1 {
+			t.Fatalf("expected: 1, real: %d", v)
+		}
+	}
+
+	if v := ParseString("[1, 2, 3]").List().Get(2).GetInt32(); v != 3 {
+		t.Fatalf("expected: 3, real: %d", v)
+	}
+
+	mustPanic(t, "content after the root array", func() { ParseString("[1] a = 2") })
+	mustPanic(t, "fallback on an array root", func() { ParseString("[1]").WithFallback(ParseString("a = 1")) })
+}
+
+func TestConfigLists(t *testing.T) {
+	conf := ParseString(`
+deployment = [
+  { name = a, replicas = 2 }
+  { name = b }
+]
+mixed = [{ name = a }, 1]
+servers {
+  a { port = 80 }
+  "b.example" { port = 81 }
+}
+labels { team = core, tier = 1, "k.8" = v, "say \"hi\"" = x, "a\\b" = y }
+`).WithFallback(ParseString(`
+servers.a.host = localhost
+labels.zone = eu
+`))
+
+	deployment := conf.GetConfigList("deployment")
+	if len(deployment) != 2 || deployment[1].GetString("name") != "b" || deployment[0].GetInt32("replicas") != 2 {
+		t.Fatalf("deployment, unexpected value: %v", deployment)
+	}
+	if v := deployment[1].GetInt32("replicas", 1); v != 1 {
+		t.Fatalf("deployment[1], expected the default replicas, real: %d", v)
+	}
+	if v := conf.GetObjectList("deployment"); len(v) != 2 || v[0].GetKey("name").GetString() != "a" {
+		t.Fatalf("deployment, unexpected objects: %v", v)
+	}
+
+	servers := conf.GetStringMap("servers")
+	if len(servers) != 2 || servers["b.example"].GetInt32("port") != 81 {
+		t.Fatalf("servers, unexpected value: %v", servers)
+	}
+	if v := servers["a"].GetString("host"); v != "localhost" {
+		t.Fatalf("servers.a.host, expected the fallback value, real: %q", v)
+	}
+
+	scalars := ParseString("servers { a { port = 80 }, b = 1 }").WithFallback(ParseString("servers { b = 2, c = 3 }")).GetStringMap("servers")
+	if len(scalars) != 3 || scalars["b"].GetInt32("") != 1 || scalars["c"].GetInt32("") != 3 || scalars["a"].GetInt32("port") != 80 {
+		t.Fatalf("servers, expected the scalar fields to win over the fallback, real: %v", scalars)
+	}
+
+	labels := conf.GetStringMapString("labels")
+	expected := map[string]string{"team": "core", "tier": "1", "k.8": "v", `say "hi"`: "x", `a\b`: "y", "zone": "eu"}
+	if len(labels) != len(expected) {
+		t.Fatalf("labels, expected: %v, real: %v", expected, labels)
+	}
+	for k, v := range expected {
+		if labels[k] != v {
+			t.Fatalf("labels.%s, expected: %q, real: %q", k, v, labels[k])
+		}
+	}
+
+	for _, path := range []string{"labels", "labels.team"} {
+		err, ok := mustPanic(t, path, func() { conf.GetConfigList(path) }).(*ConfigError)
+		if !ok || err.Path != path || err.Origin.Line == 0 || !errors.Is(err, ErrWrongType) {
+			t.Fatalf("%s, expected a wrong type error with an origin, real: %v", path, err)
+		}
+	}
+
+	err, ok := mustPanic(t, "mixed", func() { conf.GetConfigList("mixed") }).(*ConfigError)
+	if !ok || err.Path != "mixed[1]" || !errors.Is(err, ErrWrongType) {
+		t.Fatalf("expected a wrong type error for mixed[1], real: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package configuration
+
+import (
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"time"
+)
+
+// The Must getters are for required settings. They panic with the
+// *ConfigError of the matching TryGet getter when the path is missing, set
+// to null, of the wrong type or malformed.
+
+func (p *Config) MustGetString(path string) string {
+	val, err := p.TryGetString(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetBoolean(path string) bool {
+	val, err := p.TryGetBoolean(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetInt32(path string) int32 {
+	val, err := p.TryGetInt32(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetInt64(path string) int64 {
+	val, err := p.TryGetInt64(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetFloat32(path string) float32 {
+	val, err := p.TryGetFloat32(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetFloat64(path string) float64 {
+	val, err := p.TryGetFloat64(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetBigInt(path string) *big.Int {
+	val, err := p.TryGetBigInt(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetByteSize(path string) *big.Int {
+	val, err := p.TryGetByteSize(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetTimeDuration(path string) time.Duration {
+	val, err := p.TryGetTimeDuration(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetURL(path string) *url.URL {
+	val, err := p.TryGetURL(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetIP(path string) net.IP {
+	val, err := p.TryGetIP(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetIPNet(path string) *net.IPNet {
+	val, err := p.TryGetIPNet(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetRegexp(path string) *regexp.Regexp {
+	val, err := p.TryGetRegexp(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetTime(path string, layouts ...string) time.Time {
+	val, err := p.TryGetTime(path, layouts...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetLocation(path string) *time.Location {
+	val, err := p.TryGetLocation(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetFileMode(path string) os.FileMode {
+	val, err := p.TryGetFileMode(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) MustGetEnum(path string, allowed []string, opts ...EnumOptions) string {
+	val, err := p.TryGetEnum(path, allowed, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
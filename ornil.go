@@ -0,0 +1,72 @@
+package configuration
+
+import (
+	"time"
+)
+
+// The OrNil getters return nil when the path is missing or set to null, so
+// that callers can tell an unset value from a zero one. Malformed values
+// panic, as with the Get getters.
+
+func (p *Config) GetStringOrNil(path string) *string {
+	obj := p.getValue(path)
+	if obj == nil {
+		return nil
+	}
+	val := obj.GetString()
+	return &val
+}
+
+func (p *Config) GetBooleanOrNil(path string) *bool {
+	obj := p.getValue(path)
+	if obj == nil {
+		return nil
+	}
+	val := obj.GetBoolean()
+	return &val
+}
+
+func (p *Config) GetInt32OrNil(path string) *int32 {
+	obj := p.getValue(path)
+	if obj == nil {
+		return nil
+	}
+	val := obj.GetInt32()
+	return &val
+}
+
+func (p *Config) GetInt64OrNil(path string) *int64 {
+	obj := p.getValue(path)
+	if obj == nil {
+		return nil
+	}
+	val := obj.GetInt64()
+	return &val
+}
+
+func (p *Config) GetFloat32OrNil(path string) *float32 {
+	obj := p.getValue(path)
+	if obj == nil {
+		return nil
+	}
+	val := obj.GetFloat32()
+	return &val
+}
+
+func (p *Config) GetFloat64OrNil(path string) *float64 {
+	obj := p.getValue(path)
+	if obj == nil {
+		return nil
+	}
+	val := obj.GetFloat64()
+	return &val
+}
+
+func (p *Config) GetTimeDurationOrNil(path string) *time.Duration {
+	obj := p.getValue(path)
+	if obj == nil {
+		return nil
+	}
+	val := obj.GetTimeDuration(true)
+	return &val
+}
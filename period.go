@@ -0,0 +1,55 @@
+package configuration
+
+import (
+	"github.com/go-akka/configuration/hocon"
+)
+
+func (p *Config) TryGetPeriod(path string) (hocon.Period, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return hocon.Period{}, err
+	}
+	val, err := node.TryGetPeriod()
+	if err != nil {
+		return hocon.Period{}, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+func (p *Config) GetPeriod(path string, defaultVal ...hocon.Period) hocon.Period {
+	obj := p.getValue(path)
+	if obj == nil {
+		if len(defaultVal) > 0 {
+			return defaultVal[0]
+		}
+		return hocon.Period{}
+	}
+	return obj.GetPeriod()
+}
+
+// TryGetTemporal reads the value at path as a time.Duration or, for units
+// such as months and years that have no fixed length, as a Period. Unlike
+// Typesafe Config, a number without a unit is a Period of days, not a
+// duration in milliseconds.
+func (p *Config) TryGetTemporal(path string) (hocon.Temporal, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return hocon.Temporal{}, err
+	}
+	val, err := node.TryGetTemporal()
+	if err != nil {
+		return hocon.Temporal{}, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+func (p *Config) GetTemporal(path string, defaultVal ...hocon.Temporal) hocon.Temporal {
+	obj := p.getValue(path)
+	if obj == nil {
+		if len(defaultVal) > 0 {
+			return defaultVal[0]
+		}
+		return hocon.Temporal{}
+	}
+	return obj.GetTemporal()
+}
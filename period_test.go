@@ -0,0 +1,52 @@
+package configuration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-akka/configuration/hocon"
+)
+
+func TestPeriods(t *testing.T) {
+	conf := ParseString(`
+retention = 3 months
+weekly = 1 week
+days = 10
+years = 2y
+timeout = 5m
+bad = 1.5 months
+`)
+
+	expected := map[string]hocon.Period{
+		"retention": {Months: 3},
+		"weekly":    {Days: 7},
+		"days":      {Days: 10},
+		"years":     {Years: 2},
+	}
+	for path, period := range expected {
+		if v := conf.GetPeriod(path); v != period {
+			t.Fatalf("%s, expected: %s, real: %s", path, period, v)
+		}
+	}
+
+	start := time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC)
+	if v := conf.GetPeriod("retention").AddTo(start); !v.Equal(time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("retention, unexpected date: %s", v)
+	}
+
+	if v := conf.GetTemporal("timeout"); v.Period != nil || v.Duration != 5*time.Minute {
+		t.Fatalf("timeout, expected 5 minutes, real: %+v", v)
+	}
+	if v := conf.GetTemporal("retention"); v.Period == nil || *v.Period != expected["retention"] {
+		t.Fatalf("retention, expected a period, real: %+v", v)
+	}
+	// unlike Typesafe Config, which reads it as 10ms
+	if v := conf.GetTemporal("days"); v.Period == nil || v.Duration != 0 || *v.Period != conf.GetPeriod("days") {
+		t.Fatalf("days, expected the same 10 days as GetPeriod, real: %+v", v)
+	}
+
+	if _, err := conf.TryGetPeriod("bad"); !errors.Is(err, ErrBadValue) {
+		t.Fatalf("bad, expected ErrBadValue, real: %v", err)
+	}
+}
@@ -0,0 +1,326 @@
+package configuration
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-akka/configuration/hocon"
+)
+
+// The renderers below walk the resolved value tree, so substitutions are
+// already replaced by the values they point at. Values are kept in document
+// order. Since HOCON stores every scalar as text, durations and byte sizes
+// such as 10s or 1MiB are written as strings in every format; unquoted
+// numbers and the literals true and false are written as native scalars
+// where the target format has them, except that TOML integers outside the
+// int64 range are written as strings.
+
+var (
+	renderTOMLKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	renderYAMLKeyRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+)
+
+// RenderYAML renders the config as a YAML block document.
+//
+// Nulls are written as null, empty objects and arrays as {} and [], and
+// nested arrays as nested block sequences. Strings are always double quoted
+// so that values like on, off or yes keep their text.
+func (p *Config) RenderYAML() string {
+	if p.IsEmpty() {
+		return "{}\n"
+	}
+
+	buf := bytes.NewBuffer(nil)
+	renderYAMLValue(buf, p.root, 0)
+	return buf.String()
+}
+
+// RenderTOML renders the config as a TOML document.
+//
+// TOML has no null, so fields and array elements that are null are left
+// out. Objects become tables, objects inside arrays become inline tables,
+// and nested arrays are written as nested TOML arrays.
+func (p *Config) RenderTOML() string {
+	buf := bytes.NewBuffer(nil)
+	if p.IsEmpty() || !p.root.IsObject() {
+		return ""
+	}
+	renderTOMLTable(buf, p.root.GetObject(), nil)
+	return buf.String()
+}
+
+// RenderProperties renders the config as a Java .properties file with one
+// line per scalar, keyed by its dotted path.
+//
+// Array elements are keyed by their index (servers.0, servers.1, and for
+// nested arrays matrix.0.1), which is how HOCON reads numbered keys back
+// into a list. Null values, empty objects and empty arrays produce no line
+// because the format cannot express them. Key segments are written as is,
+// so a key that itself contains a dot reads back as a nested path.
+func (p *Config) RenderProperties() string {
+	buf := bytes.NewBuffer(nil)
+	if p.IsEmpty() {
+		return ""
+	}
+	renderPropertiesValue(buf, p.root, nil)
+	return buf.String()
+}
+
+func renderYAMLValue(buf *bytes.Buffer, value *hocon.HoconValue, indent int) {
+	switch {
+	case value.IsObject():
+		obj := value.GetObject()
+		if len(obj.GetKeys()) == 0 {
+			buf.WriteString("{}\n")
+			return
+		}
+		for _, k := range obj.GetKeys() {
+			buf.WriteString(strings.Repeat(" ", indent))
+			buf.WriteString(renderYAMLKey(k))
+			buf.WriteString(":")
+			renderYAMLChild(buf, obj.GetKey(k), indent+2)
+		}
+	case value.IsArray():
+		items := value.GetArray()
+		if len(items) == 0 {
+			buf.WriteString("[]\n")
+			return
+		}
+		for _, item := range items {
+			buf.WriteString(strings.Repeat(" ", indent))
+			buf.WriteString("-")
+			renderYAMLChild(buf, item, indent+2)
+		}
+	default:
+		buf.WriteString(renderYAMLScalar(value))
+		buf.WriteString("\n")
+	}
+}
+
+// renderYAMLChild writes value after a "key:" or "-" prefix, either inline
+// or as a block on the following lines.
+func renderYAMLChild(buf *bytes.Buffer, value *hocon.HoconValue, indent int) {
+	switch {
+	case value.IsObject() && len(value.GetObject().GetKeys()) > 0:
+		buf.WriteString("\n")
+		renderYAMLValue(buf, value, indent)
+	case value.IsArray() && len(value.GetArray()) > 0:
+		buf.WriteString("\n")
+		renderYAMLValue(buf, value, indent)
+	default:
+		buf.WriteString(" ")
+		renderYAMLValue(buf, value, 0)
+	}
+}
+
+func renderYAMLKey(key string) string {
+	if renderYAMLKeyRegexp.MatchString(key) && !isYAMLReserved(key) {
+		return key
+	}
+	return quoteDoubleQuoted(key)
+}
+
+// isYAMLReserved reports whether a plain scalar reads back as a boolean or
+// null rather than as the string itself, as on, no or null do in YAML 1.1.
+func isYAMLReserved(text string) bool {
+	switch strings.ToLower(text) {
+	case "y", "yes", "n", "no", "true", "false", "on", "off", "null", "~":
+		return true
+	}
+	return false
+}
+
+func renderYAMLScalar(value *hocon.HoconValue) string {
+	if isNullValue(value) {
+		return "null"
+	}
+
+	text := value.GetString()
+	if isNativeScalar(value) {
+		return text
+	}
+	return quoteDoubleQuoted(text)
+}
+
+func renderTOMLTable(buf *bytes.Buffer, obj *hocon.HoconObject, path []string) {
+	var tables []string
+
+	for _, k := range obj.GetKeys() {
+		v := obj.GetKey(k)
+		if v.IsObject() {
+			tables = append(tables, k)
+			continue
+		}
+		if isNullValue(v) {
+			continue
+		}
+		fmt.Fprintf(buf, "%s = %s\n", renderTOMLKey(k), renderTOMLInline(v))
+	}
+
+	for _, k := range tables {
+		tablePath := append(append([]string{}, path...), renderTOMLKey(k))
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(buf, "[%s]\n", strings.Join(tablePath, "."))
+		renderTOMLTable(buf, obj.GetKey(k).GetObject(), tablePath)
+	}
+}
+
+func renderTOMLInline(value *hocon.HoconValue) string {
+	switch {
+	case value.IsObject():
+		obj := value.GetObject()
+		var fields []string
+		for _, k := range obj.GetKeys() {
+			v := obj.GetKey(k)
+			if isNullValue(v) {
+				continue
+			}
+			fields = append(fields, renderTOMLKey(k)+" = "+renderTOMLInline(v))
+		}
+		if len(fields) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(fields, ", ") + " }"
+	case value.IsArray():
+		var items []string
+		for _, item := range value.GetArray() {
+			if isNullValue(item) {
+				continue
+			}
+			items = append(items, renderTOMLInline(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+
+	text := value.GetString()
+	if isNativeScalar(value) && !isTOMLOutOfRange(value) {
+		return text
+	}
+	return quoteDoubleQuoted(text)
+}
+
+// isTOMLOutOfRange reports whether value is an integer that TOML, whose
+// integers are 64-bit, cannot hold.
+func isTOMLOutOfRange(value *hocon.HoconValue) bool {
+	if !value.IsNumber() || strings.ContainsAny(value.GetString(), ".eE") {
+		return false
+	}
+	_, err := value.TryGetInt64()
+	return err != nil
+}
+
+func renderTOMLKey(key string) string {
+	if renderTOMLKeyRegexp.MatchString(key) {
+		return key
+	}
+	return quoteDoubleQuoted(key)
+}
+
+func renderPropertiesValue(buf *bytes.Buffer, value *hocon.HoconValue, path []string) {
+	switch {
+	case value.IsObject():
+		obj := value.GetObject()
+		for _, k := range obj.GetKeys() {
+			renderPropertiesValue(buf, obj.GetKey(k), append(path, k))
+		}
+	case value.IsArray():
+		for i, item := range value.GetArray() {
+			renderPropertiesValue(buf, item, append(path, strconv.Itoa(i)))
+		}
+	default:
+		if isNullValue(value) {
+			return
+		}
+		buf.WriteString(escapeProperties(strings.Join(path, "."), true))
+		buf.WriteString("=")
+		buf.WriteString(escapeProperties(value.GetString(), false))
+		buf.WriteString("\n")
+	}
+}
+
+func escapeProperties(text string, isKey bool) string {
+	buf := bytes.NewBuffer(nil)
+	for i, r := range text {
+		switch r {
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			if isKey || i == 0 {
+				buf.WriteByte('\\')
+			}
+			buf.WriteRune(r)
+		case ' ':
+			if isKey || i == 0 {
+				buf.WriteByte('\\')
+			}
+			buf.WriteRune(r)
+		default:
+			if r < ' ' || r > '~' {
+				buf.WriteString(escapeUTF16(r))
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	return buf.String()
+}
+
+func quoteDoubleQuoted(text string) string {
+	buf := bytes.NewBuffer(nil)
+	buf.WriteByte('"')
+	for _, r := range text {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < ' ' || r == 0x7f {
+				buf.WriteString(escapeUTF16(r))
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+func escapeUTF16(r rune) string {
+	if r > 0xffff {
+		r -= 0x10000
+		return fmt.Sprintf(`\u%04X\u%04X`, 0xd800+(r>>10), 0xdc00+(r&0x3ff))
+	}
+	return fmt.Sprintf(`\u%04X`, r)
+}
+
+func isNativeScalar(value *hocon.HoconValue) bool {
+	return value.IsBoolean() || value.IsNumber()
+}
+
+func isNullValue(value *hocon.HoconValue) bool {
+	return value.IsNull() || (!value.IsString() && !value.IsObject() && !value.IsArray())
+}
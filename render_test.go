@@ -0,0 +1,141 @@
+package configuration
+
+import (
+	"testing"
+)
+
+const renderTestConfig = `
+server {
+  host = "example.com"
+  port = 8080
+  timeout = 10s
+  enabled = on
+  tags = [a, "b c"]
+  matrix = [[1, 2], []]
+  backends = [{ name = x, weight = 1.5 }]
+  empty {}
+}
+password = null
+"a.b" = "line1\nline2"
+`
+
+func TestRenderYAML(t *testing.T) {
+	expected := `server:
+  host: "example.com"
+  port: 8080
+  timeout: "10s"
+  enabled: "on"
+  tags:
+    - "a"
+    - "b c"
+  matrix:
+    -
+      - 1
+      - 2
+    - []
+  backends:
+    -
+      name: "x"
+      weight: 1.5
+  empty: {}
+password: null
+"a.b": "line1\nline2"
+`
+	if actual := ParseString(renderTestConfig).RenderYAML(); actual != expected {
+		t.Fatalf("unexpected YAML:\n%s", actual)
+	}
+}
+
+func TestRenderTOML(t *testing.T) {
+	expected := `"a.b" = "line1\nline2"
+
+[server]
+host = "example.com"
+port = 8080
+timeout = "10s"
+enabled = "on"
+tags = ["a", "b c"]
+matrix = [[1, 2], []]
+backends = [{ name = "x", weight = 1.5 }]
+
+[server.empty]
+`
+	if actual := ParseString(renderTestConfig).RenderTOML(); actual != expected {
+		t.Fatalf("unexpected TOML:\n%s", actual)
+	}
+}
+
+func TestRenderProperties(t *testing.T) {
+	expected := `server.host=example.com
+server.port=8080
+server.timeout=10s
+server.enabled=on
+server.tags.0=a
+server.tags.1=b c
+server.matrix.0.0=1
+server.matrix.0.1=2
+server.backends.0.name=x
+server.backends.0.weight=1.5
+a.b=line1\nline2
+`
+	if actual := ParseString(renderTestConfig).RenderProperties(); actual != expected {
+		t.Fatalf("unexpected properties:\n%s", actual)
+	}
+}
+
+func TestRenderReservedKeysAndBigIntegers(t *testing.T) {
+	conf := ParseString(`
+on = 1
+No = 2
+null = 3
+port = 4
+big = 123456789012345678901234567890
+`)
+
+	expectedYAML := `"on": 1
+"No": 2
+"null": 3
+port: 4
+big: 123456789012345678901234567890
+`
+	if actual := conf.RenderYAML(); actual != expectedYAML {
+		t.Fatalf("unexpected YAML:\n%s", actual)
+	}
+
+	expectedTOML := `on = 1
+No = 2
+null = 3
+port = 4
+big = "123456789012345678901234567890"
+`
+	if actual := conf.RenderTOML(); actual != expectedTOML {
+		t.Fatalf("unexpected TOML:\n%s", actual)
+	}
+}
+
+func TestRenderQuotedBooleans(t *testing.T) {
+	conf := ParseString(`
+flag = true
+text = "true"
+list = [false, "false"]
+`)
+
+	expectedYAML := `flag: true
+text: "true"
+list:
+  - false
+  - "false"
+`
+	expectedTOML := `flag = true
+text = "true"
+list = [false, "false"]
+`
+	for _, c := range []*Config{conf, ParseString(conf.String())} {
+		if actual := c.RenderYAML(); actual != expectedYAML {
+			t.Fatalf("unexpected YAML:\n%s", actual)
+		}
+		if actual := c.RenderTOML(); actual != expectedTOML {
+			t.Fatalf("unexpected TOML:\n%s", actual)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package configuration
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/go-akka/configuration/hocon"
+)
+
+// The TryGet getters mirror the Get getters but return a *ConfigError
+// instead of panicking on malformed values or falling back to a zero value
+// on missing ones.
+
+// getScalar returns the node at path, which must be a string, number or
+// boolean. A null value is reported as missing.
+func (p *Config) getScalar(path string) (*hocon.HoconValue, error) {
+	node := p.GetNode(path)
+	if node == nil {
+		return nil, newConfigError(ErrMissing, path, nil, nil)
+	}
+
+	switch {
+	case node.IsNull():
+		return nil, newConfigError(ErrMissing, path, node, fmt.Errorf("value is null"))
+	case node.IsObject():
+		return nil, newConfigError(ErrWrongType, path, node, fmt.Errorf("expected a scalar, found an object"))
+	case node.IsArray():
+		return nil, newConfigError(ErrWrongType, path, node, fmt.Errorf("expected a scalar, found an array"))
+	case !node.IsString():
+		return nil, newConfigError(ErrWrongType, path, node, fmt.Errorf("expected a scalar"))
+	}
+	return node, nil
+}
+
+func (p *Config) TryGetString(path string) (string, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return "", err
+	}
+	return node.GetString(), nil
+}
+
+func (p *Config) TryGetBoolean(path string) (bool, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return false, err
+	}
+	val, err := node.TryGetBoolean()
+	if err != nil {
+		return false, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+func (p *Config) TryGetInt32(path string) (int32, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return 0, err
+	}
+	val, err := node.TryGetInt32()
+	if err != nil {
+		return 0, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+func (p *Config) TryGetInt64(path string) (int64, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return 0, err
+	}
+	val, err := node.TryGetInt64()
+	if err != nil {
+		return 0, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+func (p *Config) TryGetFloat32(path string) (float32, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return 0, err
+	}
+	val, err := node.TryGetFloat32()
+	if err != nil {
+		return 0, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+func (p *Config) TryGetFloat64(path string) (float64, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return 0, err
+	}
+	val, err := node.TryGetFloat64()
+	if err != nil {
+		return 0, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+func (p *Config) TryGetBigInt(path string) (*big.Int, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return nil, err
+	}
+	val, err := node.TryGetBigInt()
+	if err != nil {
+		return nil, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+func (p *Config) TryGetBigFloat(path string) (*big.Float, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return nil, err
+	}
+	val, err := node.TryGetBigFloat()
+	if err != nil {
+		return nil, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+func (p *Config) TryGetByteSize(path string) (*big.Int, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return nil, err
+	}
+	val, err := node.TryGetByteSize()
+	if err != nil {
+		return nil, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+// TryGetTimeDuration is TryGetDuration for a plain time.Duration. As with
+// GetTimeDuration, infinite reads as -1, which is also what -1ns reads as;
+// use TryGetDuration when the two must be told apart.
+func (p *Config) TryGetTimeDuration(path string) (time.Duration, error) {
+	val, err := p.TryGetDuration(path)
+	if err != nil {
+		return 0, err
+	}
+	if val.Infinite {
+		return -1, nil
+	}
+	return val.Duration, nil
+}
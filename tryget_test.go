@@ -0,0 +1,100 @@
+package configuration
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTryGetErrors(t *testing.T) {
+	conf := ParseString(`
+a = 42
+b = maybe
+c { x = 1 }
+d = 1.5GiB
+`)
+
+	if v, err := conf.TryGetInt64("a"); err != nil || v != 42 {
+		t.Fatalf("a, expected: 42, real: %d, %v", v, err)
+	}
+
+	_, err := conf.TryGetBoolean("b")
+	if !errors.Is(err, ErrBadValue) {
+		t.Fatalf("b, expected ErrBadValue, real: %v", err)
+	}
+	if cerr := err.(*ConfigError); cerr.Path != "b" || cerr.Origin.Line != 3 {
+		t.Fatalf("b, expected the path and origin line 3, real: %v", err)
+	}
+
+	if _, err := conf.TryGetString("c"); !errors.Is(err, ErrWrongType) {
+		t.Fatalf("c, expected ErrWrongType, real: %v", err)
+	}
+	if _, err := conf.TryGetInt32("missing"); !errors.Is(err, ErrMissing) {
+		t.Fatalf("missing, expected ErrMissing, real: %v", err)
+	}
+	if _, err := conf.TryGetInt32("d"); !errors.Is(err, ErrBadValue) {
+		t.Fatalf("d, expected ErrBadValue, real: %v", err)
+	}
+
+	loaded := LoadConfig("tests/t3.conf")
+	_, err = loaded.TryGetInt32("akka.loglevel")
+	if !strings.Contains(err.Error(), "tests/t3.conf: 3") {
+		t.Fatalf("expected the file and line in the error, real: %v", err)
+	}
+}
+
+func TestNullAndDefaults(t *testing.T) {
+	conf := ParseString(`
+a = null
+b = 0
+c = ""
+e = "null"
+f = """null"""
+g = ${e}
+h = ${a}
+`)
+
+	if v := conf.GetString("a", "d"); v != "d" {
+		t.Fatalf("a, expected the default for null, real: %q", v)
+	}
+	if v := conf.GetFloat32("missing"); v != 0 {
+		t.Fatalf("missing, expected: 0, real: %v", v)
+	}
+	if v := conf.GetInt32("b", 7); v != 0 {
+		t.Fatalf("b, expected: 0, real: %d", v)
+	}
+	if v := conf.GetString("c", "d"); v != "" {
+		t.Fatalf("c, expected the empty string, real: %q", v)
+	}
+
+	for _, path := range []string{"e", "f", "g"} {
+		if v := conf.GetString(path, "d"); v != "null" {
+			t.Fatalf("%s, expected the string null, real: %q", path, v)
+		}
+	}
+	if v := conf.GetString("h", "d"); v != "d" {
+		t.Fatalf("h, expected the default for null, real: %q", v)
+	}
+	if v := ParseJSON(`{"a": "null"}`).GetString("a", "d"); v != "null" {
+		t.Fatalf("a, expected the JSON string null, real: %q", v)
+	}
+	if v := ParseString(conf.String()).GetString("e", "d"); v != "null" {
+		t.Fatalf("e, expected the string null after a round trip, real: %q", v)
+	}
+
+	if v := conf.GetInt32OrNil("a"); v != nil {
+		t.Fatalf("a, expected nil, real: %d", *v)
+	}
+	if v := conf.GetInt32OrNil("b"); v == nil || *v != 0 {
+		t.Fatalf("b, expected a pointer to 0, real: %v", v)
+	}
+
+	if _, err := conf.TryGetString("a"); !errors.Is(err, ErrMissing) {
+		t.Fatalf("a, expected ErrMissing, real: %v", err)
+	}
+
+	err, ok := mustPanic(t, "missing", func() { conf.MustGetString("missing") }).(*ConfigError)
+	if !ok || err.Path != "missing" || !errors.Is(err, ErrMissing) {
+		t.Fatalf("expected a ConfigError for the missing path, real: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package configuration
+
+import (
+	"encoding"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// The getters below parse common Go types from string values. They return
+// a *ConfigError naming the path and origin of the value, so that bad
+// settings are reported when the config is loaded.
+
+// getText reads the scalar at path and hands its text to parse, wrapping a
+// parse error as ErrBadValue.
+func (p *Config) getText(path string, parse func(text string) error) error {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return err
+	}
+	if err := parse(node.GetString()); err != nil {
+		return newConfigError(ErrBadValue, path, node, err)
+	}
+	return nil
+}
+
+// TryGetURL reads an absolute URL, such as https://example.com/api.
+func (p *Config) TryGetURL(path string) (*url.URL, error) {
+	var val *url.URL
+	err := p.getText(path, func(text string) (err error) {
+		val, err = url.Parse(text)
+		if err == nil && !val.IsAbs() {
+			err = fmt.Errorf("%q is not an absolute URL", text)
+		}
+		return
+	})
+	return val, err
+}
+
+// TryGetIP reads an IPv4 or IPv6 address.
+func (p *Config) TryGetIP(path string) (net.IP, error) {
+	var val net.IP
+	err := p.getText(path, func(text string) error {
+		if val = net.ParseIP(text); val == nil {
+			return fmt.Errorf("%q is not an IP address", text)
+		}
+		return nil
+	})
+	return val, err
+}
+
+// TryGetIPNet reads a network in CIDR notation, such as 10.0.0.0/8.
+func (p *Config) TryGetIPNet(path string) (*net.IPNet, error) {
+	var val *net.IPNet
+	err := p.getText(path, func(text string) (err error) {
+		_, val, err = net.ParseCIDR(text)
+		return
+	})
+	return val, err
+}
+
+func (p *Config) TryGetRegexp(path string) (*regexp.Regexp, error) {
+	var val *regexp.Regexp
+	err := p.getText(path, func(text string) (err error) {
+		val, err = regexp.Compile(text)
+		return
+	})
+	return val, err
+}
+
+// TryGetTime reads a time in the first of layouts that matches, or in
+// RFC 3339 if no layout is given.
+func (p *Config) TryGetTime(path string, layouts ...string) (time.Time, error) {
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339Nano}
+	}
+
+	var val time.Time
+	err := p.getText(path, func(text string) (err error) {
+		for _, layout := range layouts {
+			if val, err = time.Parse(layout, text); err == nil {
+				return nil
+			}
+		}
+		return
+	})
+	return val, err
+}
+
+// TryGetLocation reads a time zone name such as UTC or Europe/Berlin.
+func (p *Config) TryGetLocation(path string) (*time.Location, error) {
+	var val *time.Location
+	err := p.getText(path, func(text string) (err error) {
+		val, err = time.LoadLocation(text)
+		return
+	})
+	return val, err
+}
+
+// TryGetFileMode reads octal permission bits, such as 0644 or 755.
+func (p *Config) TryGetFileMode(path string) (os.FileMode, error) {
+	var val os.FileMode
+	err := p.getText(path, func(text string) error {
+		bits, err := strconv.ParseUint(text, 8, 32)
+		if err != nil || bits > 07777 {
+			return fmt.Errorf("%q is not an octal file mode", text)
+		}
+		val = os.FileMode(bits & uint64(os.ModePerm))
+		if bits&04000 != 0 {
+			val |= os.ModeSetuid
+		}
+		if bits&02000 != 0 {
+			val |= os.ModeSetgid
+		}
+		if bits&01000 != 0 {
+			val |= os.ModeSticky
+		}
+		return nil
+	})
+	return val, err
+}
+
+// TryGetTextUnmarshaler passes the text at path to u.UnmarshalText.
+func (p *Config) TryGetTextUnmarshaler(path string, u encoding.TextUnmarshaler) error {
+	return p.getText(path, func(text string) error {
+		return u.UnmarshalText([]byte(text))
+	})
+}
@@ -0,0 +1,74 @@
+package configuration
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestTypedGetters(t *testing.T) {
+	conf := ParseString(`
+endpoint = "https://example.com/api?x=1"
+relative = "/api"
+ip = "10.1.2.3"
+net = "10.0.0.0/8"
+pattern = "^a+$"
+badPattern = "a("
+started = "2020-01-02T03:04:05Z"
+day = "2020-01-02"
+zone = UTC
+mode = 0640
+badMode = 0899
+`)
+
+	if v, err := conf.TryGetURL("endpoint"); err != nil || v.Host != "example.com" {
+		t.Fatalf("endpoint, unexpected value: %v, %v", v, err)
+	}
+	if _, err := conf.TryGetURL("relative"); !errors.Is(err, ErrBadValue) {
+		t.Fatalf("relative, expected ErrBadValue, real: %v", err)
+	}
+	if v, err := conf.TryGetIP("ip"); err != nil || !v.Equal(net.IPv4(10, 1, 2, 3)) {
+		t.Fatalf("ip, unexpected value: %v, %v", v, err)
+	}
+	if v, err := conf.TryGetIPNet("net"); err != nil || !v.Contains(net.IPv4(10, 9, 9, 9)) {
+		t.Fatalf("net, unexpected value: %v, %v", v, err)
+	}
+	if v, err := conf.TryGetRegexp("pattern"); err != nil || !v.MatchString("aaa") {
+		t.Fatalf("pattern, unexpected value: %v, %v", v, err)
+	}
+	if _, err := conf.TryGetRegexp("badPattern"); !errors.Is(err, ErrBadValue) {
+		t.Fatalf("badPattern, expected ErrBadValue, real: %v", err)
+	}
+	if v, err := conf.TryGetTime("started"); err != nil || v.Hour() != 3 {
+		t.Fatalf("started, unexpected value: %v, %v", v, err)
+	}
+	if v, err := conf.TryGetTime("day", time.RFC3339, "2006-01-02"); err != nil || v.Day() != 2 {
+		t.Fatalf("day, unexpected value: %v, %v", v, err)
+	}
+	if v, err := conf.TryGetLocation("zone"); err != nil || v != time.UTC {
+		t.Fatalf("zone, unexpected value: %v, %v", v, err)
+	}
+	if v, err := conf.TryGetFileMode("mode"); err != nil || v != 0640 {
+		t.Fatalf("mode, unexpected value: %v, %v", v, err)
+	}
+	if _, err := conf.TryGetFileMode("badMode"); !errors.Is(err, ErrBadValue) {
+		t.Fatalf("badMode, expected ErrBadValue, real: %v", err)
+	}
+
+	var ip net.IP
+	if err := conf.TryGetTextUnmarshaler("ip", &ip); err != nil || ip.String() != "10.1.2.3" {
+		t.Fatalf("ip, unexpected value: %v, %v", ip, err)
+	}
+	if err := conf.TryGetTextUnmarshaler("pattern", &ip); !errors.Is(err, ErrBadValue) {
+		t.Fatalf("pattern, expected ErrBadValue, real: %v", err)
+	}
+
+	if v := conf.MustGetIP("ip"); !v.Equal(net.IPv4(10, 1, 2, 3)) {
+		t.Fatalf("ip, unexpected value: %v", v)
+	}
+	err, ok := mustPanic(t, "badMode", func() { conf.MustGetFileMode("badMode") }).(*ConfigError)
+	if !ok || err.Path != "badMode" || !errors.Is(err, ErrBadValue) {
+		t.Fatalf("badMode, expected a ConfigError, real: %v", err)
+	}
+}
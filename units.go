@@ -0,0 +1,126 @@
+package configuration
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/go-akka/configuration/hocon"
+)
+
+func (p *Config) TryGetBytesInt64(path string) (int64, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return 0, err
+	}
+	val, err := node.TryGetBytesInt64()
+	if err != nil {
+		return 0, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+// GetBytesInt64 returns the byte size at path in bytes. Unlike GetByteSize
+// it returns the default, or 0, for a missing path, and panics with a
+// *ConfigError if the size does not fit an int64.
+func (p *Config) GetBytesInt64(path string, defaultVal ...int64) int64 {
+	if p.getValue(path) == nil {
+		if len(defaultVal) > 0 {
+			return defaultVal[0]
+		}
+		return 0
+	}
+	val, err := p.TryGetBytesInt64(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func (p *Config) GetByteSizeList(path string) []*big.Int {
+	var items []*big.Int
+	for i, v := range p.getList(path) {
+		item, err := v.TryGetByteSize()
+		if err != nil {
+			panic(newConfigError(ErrBadValue, elementPath(path, i), v, err))
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// GetDurationList reads a list such as [100ms, 1s, 5s]. Infinite is not
+// allowed in lists.
+func (p *Config) GetDurationList(path string) []time.Duration {
+	var items []time.Duration
+	for i, v := range p.getList(path) {
+		item, err := v.TryGetTimeDuration(false)
+		if err != nil {
+			panic(newConfigError(ErrBadValue, elementPath(path, i), v, err))
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// TryGetDurationIn returns the duration at path as a whole number of unit,
+// truncated toward zero, so that 1500ms in time.Second is 1.
+func (p *Config) TryGetDurationIn(path string, unit time.Duration) (int64, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return 0, err
+	}
+	val, err := node.TryGetTimeDuration(false)
+	if err != nil {
+		return 0, newConfigError(ErrBadValue, path, node, err)
+	}
+	return int64(val / unit), nil
+}
+
+func (p *Config) GetDurationIn(path string, unit time.Duration, defaultVal ...int64) int64 {
+	if p.getValue(path) == nil {
+		if len(defaultVal) > 0 {
+			return defaultVal[0]
+		}
+		return 0
+	}
+	val, err := p.TryGetDurationIn(path, unit)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// TryGetDuration reads the value at path as a signed duration, with numbers
+// without a unit read as milliseconds. Infinite is reported through
+// Duration.IsInfinite rather than a magic value.
+func (p *Config) TryGetDuration(path string) (hocon.Duration, error) {
+	return p.TryGetDurationWithUnit(path, time.Millisecond)
+}
+
+// TryGetDurationWithUnit is TryGetDuration with numbers without a unit read
+// in defaultUnit.
+func (p *Config) TryGetDurationWithUnit(path string, defaultUnit time.Duration) (hocon.Duration, error) {
+	node, err := p.getScalar(path)
+	if err != nil {
+		return hocon.Duration{}, err
+	}
+	val, err := node.TryGetDuration(defaultUnit)
+	if err != nil {
+		return hocon.Duration{}, newConfigError(ErrBadValue, path, node, err)
+	}
+	return val, nil
+}
+
+func (p *Config) GetDuration(path string, defaultVal ...hocon.Duration) hocon.Duration {
+	if p.getValue(path) == nil {
+		if len(defaultVal) > 0 {
+			return defaultVal[0]
+		}
+		return hocon.Duration{}
+	}
+	val, err := p.TryGetDuration(path)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
@@ -0,0 +1,120 @@
+package configuration
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnitLists(t *testing.T) {
+	conf := ParseString(`
+retry-backoffs = [100ms, 1s, 5s]
+buffers = [1KiB, 2MB]
+huge = 10EiB
+timeout = 1500ms
+bad = [1s, soon]
+`)
+
+	if v := conf.GetDurationList("retry-backoffs"); len(v) != 3 || v[0] != 100*time.Millisecond || v[2] != 5*time.Second {
+		t.Fatalf("retry-backoffs, unexpected value: %v", v)
+	}
+	if v := conf.GetByteSizeList("buffers"); len(v) != 2 || v[0].Int64() != 1024 || v[1].Int64() != 2000000 {
+		t.Fatalf("buffers, unexpected value: %v", v)
+	}
+
+	if v := conf.GetBytesInt64("missing", 4); v != 4 {
+		t.Fatalf("missing, expected the default, real: %d", v)
+	}
+	if _, err := conf.TryGetBytesInt64("huge"); !errors.Is(err, ErrBadValue) {
+		t.Fatalf("huge, expected ErrBadValue, real: %v", err)
+	}
+
+	if v := conf.GetDurationIn("timeout", time.Second); v != 1 {
+		t.Fatalf("timeout, expected: 1, real: %d", v)
+	}
+	if v := conf.GetDurationIn("timeout", time.Microsecond); v != 1500000 {
+		t.Fatalf("timeout, expected: 1500000, real: %d", v)
+	}
+
+	err, ok := mustPanic(t, "bad", func() { conf.GetDurationList("bad") }).(*ConfigError)
+	if !ok || err.Path != "bad[1]" {
+		t.Fatalf("expected an error for bad[1], real: %v", err)
+	}
+}
+
+func TestExactByteSizes(t *testing.T) {
+	conf := ParseString(`
+a = 1.5GiB
+b = 1024
+c = 0.5kB
+d = 1.5B
+e = -1MiB
+f = 2 furlongs
+`)
+
+	expected := map[string]int64{"a": 1610612736, "b": 1024, "c": 500}
+	for path, size := range expected {
+		if v := conf.GetByteSize(path); v.Int64() != size {
+			t.Fatalf("%s, expected: %d, real: %s", path, size, v)
+		}
+	}
+
+	for _, path := range []string{"d", "e", "f"} {
+		if _, err := conf.TryGetByteSize(path); !errors.Is(err, ErrBadValue) {
+			t.Fatalf("%s, expected ErrBadValue, real: %v", path, err)
+		}
+	}
+}
+
+func TestDurations(t *testing.T) {
+	conf := ParseString(`
+negative = -1.5s
+unitless = 250
+forever = infinite
+precise = 9223372036854775807ns
+overflow = 9223372036854775808ns
+tooLong = 106752 days
+unknownUnit = 300 years
+micros = 3us
+`)
+
+	if v := conf.GetDuration("negative"); v.Duration != -1500*time.Millisecond {
+		t.Fatalf("negative, expected: -1.5s, real: %s", v)
+	}
+	if v := conf.GetDuration("unitless"); v.Duration != 250*time.Millisecond {
+		t.Fatalf("unitless, expected: 250ms, real: %s", v)
+	}
+	if v, _ := conf.TryGetDurationWithUnit("unitless", time.Second); v.Duration != 250*time.Second {
+		t.Fatalf("unitless, expected: 250s, real: %s", v)
+	}
+	if v := conf.GetDuration("forever"); !v.IsInfinite() || v.Duration != 0 {
+		t.Fatalf("forever, expected infinite, real: %+v", v)
+	}
+	if v := conf.GetDuration("precise"); v.Duration != time.Duration(9223372036854775807) {
+		t.Fatalf("precise, expected the maximum duration, real: %d", v.Duration)
+	}
+	if v := conf.GetDuration("micros"); v.Duration != 3*time.Microsecond {
+		t.Fatalf("micros, expected: 3us, real: %s", v)
+	}
+
+	for path, message := range map[string]string{
+		"overflow":    "overflows time.Duration",
+		"tooLong":     "overflows time.Duration",
+		"unknownUnit": "is not a duration",
+	} {
+		if _, err := conf.TryGetDuration(path); !errors.Is(err, ErrBadValue) || !strings.Contains(err.Error(), message) {
+			t.Fatalf("%s, expected ErrBadValue with %q, real: %v", path, message, err)
+		}
+	}
+
+	if v := conf.GetTimeDuration("forever"); v != -1 {
+		t.Fatalf("forever, expected the legacy -1, real: %d", v)
+	}
+	if v, err := conf.TryGetTimeDuration("forever"); err != nil || v != -1 {
+		t.Fatalf("forever, expected the legacy -1, real: %d, %v", v, err)
+	}
+	if v, err := conf.TryGetTimeDuration("negative"); err != nil || v != -1500*time.Millisecond {
+		t.Fatalf("negative, expected: -1.5s, real: %s, %v", v, err)
+	}
+}